@@ -3,7 +3,9 @@ package main
 import (
 	"fmt"
 	"log"
+	"time"
 
+	"football-management-api/internal/auth"
 	"football-management-api/internal/config"
 	"football-management-api/internal/routes"
 	"football-management-api/pkg/logger"
@@ -27,6 +29,12 @@ func main() {
 		cfg.App.Env,
 	))
 
+	// Load JWT signing keys
+	if err := auth.InitKeys(cfg.JWT); err != nil {
+		log.Fatal("Failed to load JWT keys:", err)
+	}
+	auth.StartKeyReload(time.Duration(cfg.JWT.KeysReloadInterval) * time.Minute)
+
 	// Initialize database
 	_, err = config.InitDatabase(cfg)
 	if err != nil {
@@ -0,0 +1,10 @@
+-- Migration: Add Live match status and goal updates
+-- Description: Status 'Live' untuk pertandingan yang sedang berlangsung dan kolom updated_at untuk koreksi gol
+
+ALTER TYPE match_status ADD VALUE IF NOT EXISTS 'Live' AFTER 'Scheduled';
+
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+-- Trigger untuk auto-update updated_at
+CREATE TRIGGER update_goals_updated_at BEFORE UPDATE ON goals
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,19 @@
+-- Migration: Add fuzzy search indexes
+-- Description: Extension pg_trgm dan unaccent untuk pencarian nama yang toleran typo dan tanpa aksen
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() hanya STABLE sehingga tidak bisa dipakai di index; bungkus dengan dictionary eksplisit
+CREATE OR REPLACE FUNCTION search_normalize(value TEXT) RETURNS TEXT AS $$
+    SELECT lower(public.unaccent('public.unaccent', value))
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+-- Trigram index untuk pencarian fuzzy
+CREATE INDEX IF NOT EXISTS idx_teams_name_trgm ON teams USING GIN (search_normalize(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_teams_home_city_trgm ON teams USING GIN (search_normalize(home_city) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_players_name_trgm ON players USING GIN (search_normalize(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+
+-- Full-text index untuk pencarian per kata
+CREATE INDEX IF NOT EXISTS idx_teams_search_fts ON teams USING GIN (to_tsvector('simple', search_normalize(name || ' ' || home_city))) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_players_search_fts ON players USING GIN (to_tsvector('simple', search_normalize(name))) WHERE deleted_at IS NULL;
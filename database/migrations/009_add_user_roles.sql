@@ -0,0 +1,15 @@
+-- Migration: Add user roles
+-- Description: Peran pengguna untuk hak akses (admin liga, manajer tim, ofisial pertandingan, viewer)
+
+-- Create ENUM type for user role
+CREATE TYPE user_role AS ENUM ('admin', 'team_manager', 'match_official', 'viewer');
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role user_role NOT NULL DEFAULT 'viewer';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS team_id INTEGER NULL REFERENCES teams(id);
+
+-- Manajer tim selalu terikat ke satu tim
+ALTER TABLE users ADD CONSTRAINT team_manager_has_team
+    CHECK (role <> 'team_manager' OR team_id IS NOT NULL);
+
+-- Jadikan pengguna pertama sebagai admin liga:
+-- UPDATE users SET role = 'admin' WHERE username = '<username>';
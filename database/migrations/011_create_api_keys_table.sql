@@ -0,0 +1,20 @@
+-- Migration: Create API keys table
+-- Description: API key untuk integrasi antar layanan (scoreboard, data feed) dengan scope per key
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) UNIQUE NOT NULL, -- Bagian awal key untuk identifikasi, aman ditampilkan
+    key_hash VARCHAR(64) NOT NULL, -- SHA-256 dari key lengkap, key asli tidak disimpan
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    last_used_at TIMESTAMP NULL DEFAULT NULL,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    created_by INTEGER NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE TRIGGER update_api_keys_updated_at BEFORE UPDATE ON api_keys
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,20 @@
+-- Migration: Create audit logs table
+-- Description: Catatan setiap perubahan data tim, pemain, pertandingan, dan gol beserta pelakunya
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    entity VARCHAR(20) NOT NULL, -- team, player, match, goal
+    entity_id INTEGER NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    actor_user_id INTEGER NULL, -- Tidak memakai foreign key agar catatan tetap utuh
+    actor_api_key_id INTEGER NULL,
+    actor_name VARCHAR(100) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '{}', -- {"field": {"before": ..., "after": ...}}
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for audit filters
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity, entity_id);
+CREATE INDEX idx_audit_logs_actor_name ON audit_logs(LOWER(actor_name));
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
@@ -0,0 +1,10 @@
+-- Migration: Add trash support
+-- Description: Nama tim hanya unik di antara tim aktif, agar tim yang dihapus tidak mengunci namanya
+--              dan dapat dipulihkan; audit log mencatat pemulihan dan penghapusan permanen
+
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS unique_team_name ON teams(name) WHERE deleted_at IS NULL;
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_action_check;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge'));
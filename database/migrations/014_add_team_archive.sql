@@ -0,0 +1,5 @@
+-- Migration: Add team archive
+-- Description: Tim yang diarsipkan tetap tampil di riwayat pertandingan dan laporan, tetapi tidak
+--              dapat lagi dipakai untuk pertandingan atau pemain baru
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP NULL DEFAULT NULL;
@@ -0,0 +1,14 @@
+-- Migration: Add goal team
+-- Description: Gol mencatat tim tempat pencetak gol bermain saat gol tercipta, agar skor dan riwayat
+--              pertandingan tetap benar setelah pemain pindah tim
+
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS team_id INTEGER NULL;
+
+-- Gol yang sudah ada dicatat untuk tim pemain saat ini
+UPDATE goals g SET team_id = p.team_id FROM players p WHERE g.player_id = p.id AND g.team_id IS NULL;
+
+ALTER TABLE goals ALTER COLUMN team_id SET NOT NULL;
+ALTER TABLE goals DROP CONSTRAINT IF EXISTS goals_team_id_fkey;
+ALTER TABLE goals ADD CONSTRAINT goals_team_id_fkey FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_goals_team_id ON goals(team_id);
@@ -0,0 +1,10 @@
+-- Migration: Add goal minute
+-- Description: Menit gol sebagai angka agar gol dapat diurutkan secara kronologis; goal_time berupa teks
+--              sehingga "10" terurut sebelum "9". "45+2" menjadi minute 45 dan stoppage_minute 2
+
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS minute INTEGER
+    GENERATED ALWAYS AS (COALESCE(substring(goal_time FROM '^[0-9]+')::INTEGER, 0)) STORED;
+ALTER TABLE goals ADD COLUMN IF NOT EXISTS stoppage_minute INTEGER
+    GENERATED ALWAYS AS (COALESCE(substring(goal_time FROM '\+([0-9]+)')::INTEGER, 0)) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_goals_match_minute ON goals(match_id, minute, stoppage_minute);
@@ -0,0 +1,38 @@
+-- Migration: Create data version
+-- Description: Penanda perubahan data laporan. Setiap perubahan pada tim, pemain, pertandingan, atau
+--              gol (termasuk penghapusan permanen) menaikkan version dan memajukan changed_at, sehingga
+--              caching laporan tidak perlu memindai tabel dan tidak pernah mundur
+
+CREATE TABLE IF NOT EXISTS data_version (
+    id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    version BIGINT NOT NULL DEFAULT 1,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO data_version (id) VALUES (1) ON CONFLICT (id) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION bump_data_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE data_version
+    SET version = version + 1, changed_at = GREATEST(changed_at, CURRENT_TIMESTAMP)
+    WHERE id = 1;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS bump_data_version_teams ON teams;
+CREATE TRIGGER bump_data_version_teams AFTER INSERT OR UPDATE OR DELETE ON teams
+FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+
+DROP TRIGGER IF EXISTS bump_data_version_players ON players;
+CREATE TRIGGER bump_data_version_players AFTER INSERT OR UPDATE OR DELETE ON players
+FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+
+DROP TRIGGER IF EXISTS bump_data_version_matches ON matches;
+CREATE TRIGGER bump_data_version_matches AFTER INSERT OR UPDATE OR DELETE ON matches
+FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
+
+DROP TRIGGER IF EXISTS bump_data_version_goals ON goals;
+CREATE TRIGGER bump_data_version_goals AFTER INSERT OR UPDATE OR DELETE ON goals
+FOR EACH STATEMENT EXECUTE FUNCTION bump_data_version();
@@ -0,0 +1,6 @@
+-- Migration: Add player team joined date
+-- Description: Pemain mencatat tanggal bergabung dengan timnya saat ini, agar statistik bertahan
+--              (clean sheet dan gol kebobolan) hanya menghitung pertandingan selama pemain berada di tim
+
+-- NULL berarti pemain terdaftar di tim sejak awal, sehingga seluruh pertandingan tim dihitung
+ALTER TABLE players ADD COLUMN IF NOT EXISTS team_joined_at DATE NULL;
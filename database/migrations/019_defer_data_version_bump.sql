@@ -0,0 +1,21 @@
+-- Migration: Defer data version bump
+-- Description: Trigger perubahan data tidak lagi mengunci baris data_version di tengah transaksi
+--              aplikasi. Trigger hanya menandai transaksi, lalu aplikasi menaikkan version sekali
+--              tepat sebelum commit sehingga kunci baris itu selalu menjadi kunci terakhir yang
+--              diambil dan tidak dapat membuat deadlock dengan kunci baris tim, pemain,
+--              pertandingan, atau gol. Perubahan di luar aplikasi (psql, seeder) tetap langsung
+--              menaikkan version
+
+CREATE OR REPLACE FUNCTION bump_data_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('app.unit_of_work', true) = 'on' THEN
+        PERFORM set_config('app.data_changed', 'on', true);
+    ELSE
+        UPDATE data_version
+        SET version = version + 1, changed_at = GREATEST(changed_at, CURRENT_TIMESTAMP)
+        WHERE id = 1;
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
@@ -0,0 +1,181 @@
+// Package apperror defines the typed errors returned by the repository and service layers.
+// Every error has a kind, which decides the HTTP status it is answered with, and a stable
+// machine-readable code clients can switch on instead of parsing the message.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Kind classifies an error by how a client can react to it
+type Kind string
+
+const (
+	// KindValidation means the request itself is invalid
+	KindValidation Kind = "validation"
+	// KindUnauthorized means the caller could not be authenticated
+	KindUnauthorized Kind = "unauthorized"
+	// KindForbidden means the caller may not perform the action
+	KindForbidden Kind = "forbidden"
+	// KindNotFound means the addressed record does not exist
+	KindNotFound Kind = "not_found"
+	// KindConflict means the request conflicts with other records, e.g. a duplicate name
+	KindConflict Kind = "conflict"
+	// KindPrecondition means the record changed since the client read it
+	KindPrecondition Kind = "precondition"
+	// KindStateTransition means the record is not in a state that allows the action
+	KindStateTransition Kind = "state_transition"
+)
+
+// statuses maps each kind to the HTTP status it is answered with
+var statuses = map[Kind]int{
+	KindValidation:      http.StatusBadRequest,
+	KindUnauthorized:    http.StatusUnauthorized,
+	KindForbidden:       http.StatusForbidden,
+	KindNotFound:        http.StatusNotFound,
+	KindConflict:        http.StatusConflict,
+	KindPrecondition:    http.StatusPreconditionFailed,
+	KindStateTransition: http.StatusUnprocessableEntity,
+}
+
+// Generic codes, used when no more specific code applies
+const (
+	CodeBadRequest           = "BAD_REQUEST"
+	CodeMalformedRequest     = "MALFORMED_REQUEST"
+	CodeValidationFailed     = "VALIDATION_FAILED"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeNotFound             = "NOT_FOUND"
+	CodeConflict             = "CONFLICT"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	CodePreconditionFailed   = "PRECONDITION_FAILED"
+	CodePreconditionRequired = "PRECONDITION_REQUIRED"
+	CodeInternal             = "INTERNAL_ERROR"
+)
+
+// Codes of failed field rules
+const (
+	FieldRequired      = "REQUIRED"
+	FieldInvalid       = "INVALID"
+	FieldInvalidType   = "INVALID_TYPE"
+	FieldInvalidFormat = "INVALID_FORMAT"
+	FieldInvalidChoice = "INVALID_CHOICE"
+	FieldMin           = "MIN"
+	FieldMax           = "MAX"
+	FieldOutOfRange    = "OUT_OF_RANGE"
+	FieldLength        = "LENGTH"
+	FieldSameAs        = "SAME_AS"
+	FieldConflictsWith = "CONFLICTS_WITH"
+	FieldBefore        = "BEFORE"
+	FieldCountMismatch = "COUNT_MISMATCH"
+	FieldNotAllowed    = "NOT_ALLOWED"
+)
+
+// FieldError is a failed validation rule of a single request field. Params holds the values
+// the rule was checked against, e.g. the minimum and maximum of a range.
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+	Params  map[string]interface{}
+}
+
+// Error is an error with a kind and a stable code. Validation errors of a request list every
+// failed field rule in Fields.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is an Error with the same code, so an error still matches its
+// sentinel after WithDetail
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Status returns the HTTP status the error is answered with
+func (e *Error) Status() int {
+	if status, ok := statuses[e.Kind]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithDetail returns a copy of the error whose message ends with the detail
+func (e *Error) WithDetail(detail string) *Error {
+	return &Error{Kind: e.Kind, Code: e.Code, Message: e.Message + ": " + detail, Fields: e.Fields}
+}
+
+// New returns an error of the kind
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Validation returns an error for an invalid request
+func Validation(code, message string) *Error {
+	return New(KindValidation, code, message)
+}
+
+// Unauthorized returns an error for a caller that could not be authenticated
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+// Forbidden returns an error for an action the caller may not perform
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+// NotFound returns an error for a record that does not exist
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+// Conflict returns an error for a request that conflicts with other records
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+// Precondition returns an error for a record that changed since the client read it
+func Precondition(code, message string) *Error {
+	return New(KindPrecondition, code, message)
+}
+
+// StateTransition returns an error for a record whose state does not allow the action
+func StateTransition(code, message string) *Error {
+	return New(KindStateTransition, code, message)
+}
+
+// InvalidFields returns a validation error listing the failed field rules. Its message joins
+// the messages of the fields.
+func InvalidFields(fields []FieldError) *Error {
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Message
+	}
+	return &Error{
+		Kind:    KindValidation,
+		Code:    CodeValidationFailed,
+		Message: strings.Join(messages, "; "),
+		Fields:  fields,
+	}
+}
+
+// As returns the first Error in err's chain
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// apiKeyPrefix marks API keys so they are easy to recognise, e.g. in secret scanners
+const apiKeyPrefix = "fmk_"
+
+// NewAPIKey generates an API key of the form "fmk_<id>.<secret>". The prefix "fmk_<id>"
+// identifies the key and may be shown; the full key is only returned once and stored as a hash.
+func NewAPIKey() (key, prefix, keyHash string, err error) {
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", "", err
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	prefix = apiKeyPrefix + hex.EncodeToString(id)
+	key = prefix + "." + secret
+	return key, prefix, HashToken(key), nil
+}
+
+// ParseAPIKey returns the prefix of an API key, or false when the key is malformed
+func ParseAPIKey(key string) (prefix string, ok bool) {
+	prefix, secret, found := strings.Cut(key, ".")
+	if !found || secret == "" || !strings.HasPrefix(prefix, apiKeyPrefix) {
+		return "", false
+	}
+	return prefix, true
+}
+
+// CheckAPIKey reports whether the key matches the stored hash
+func CheckAPIKey(key, keyHash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashToken(key)), []byte(keyHash)) == 1
+}
@@ -0,0 +1,280 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"football-management-api/internal/config"
+	"football-management-api/pkg/logger"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// activateAtHeader is the optional PEM header that schedules when a key starts signing tokens,
+// e.g. "Activate-At: 2026-01-01T00:00:00Z". Keys are published in the JWKS as soon as they are
+// loaded, so other services already know a key by the time it signs its first token.
+const activateAtHeader = "Activate-At"
+
+// signingKey is a key of the key ring. Keys loaded from a PUBLIC KEY file only verify tokens.
+type signingKey struct {
+	id         string
+	method     jwt.SigningMethod
+	private    crypto.Signer // nil for verify-only keys
+	public     crypto.PublicKey
+	activateAt time.Time
+}
+
+// keyRing holds the keys tokens are signed and verified with. Without keys it falls back to
+// HMAC with the shared secret.
+type keyRing struct {
+	mu     sync.RWMutex
+	dir    string
+	keys   map[string]*signingKey
+	secret []byte
+}
+
+var keys *keyRing
+
+// InitKeys loads the signing keys of JWTConfig.KeysDir. Every *.pem file is a key whose kid is
+// the file name without extension: RSA keys sign with RS256 and Ed25519 keys with EdDSA.
+// Without a keys directory tokens are signed with HS256 and JWTConfig.Secret.
+func InitKeys(jwtConfig config.JWTConfig) error {
+	ring := &keyRing{
+		dir:    jwtConfig.KeysDir,
+		secret: []byte(jwtConfig.Secret),
+	}
+
+	if ring.dir != "" {
+		if err := ring.reload(); err != nil {
+			return err
+		}
+	}
+
+	keys = ring
+	return nil
+}
+
+// StartKeyReload reloads the keys directory every interval, so keys can be added for a
+// rotation and removed once retired without restarting the server. A directory that fails
+// to load keeps the previous keys.
+func StartKeyReload(interval time.Duration) {
+	if keys == nil || keys.dir == "" || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := keys.reload(); err != nil {
+				logger.Error("Failed to reload JWT keys: " + err.Error())
+			}
+		}
+	}()
+}
+
+// reload replaces the keys with the PEM files of the keys directory
+func (r *keyRing) reload() error {
+	files, err := filepath.Glob(filepath.Join(r.dir, "*.pem"))
+	if err != nil {
+		return err
+	}
+
+	loaded := map[string]*signingKey{}
+	hasPrivate := false
+	for _, file := range files {
+		key, err := loadKey(file)
+		if err != nil {
+			return fmt.Errorf("failed to load JWT key %s: %w", file, err)
+		}
+		loaded[key.id] = key
+		hasPrivate = hasPrivate || key.private != nil
+	}
+
+	if !hasPrivate {
+		return fmt.Errorf("no private key found in %s", r.dir)
+	}
+
+	r.mu.Lock()
+	r.keys = loaded
+	r.mu.Unlock()
+
+	return nil
+}
+
+// loadKey reads a PEM encoded private or public key
+func loadKey(file string) (*signingKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data")
+	}
+
+	key := &signingKey{id: strings.TrimSuffix(filepath.Base(file), ".pem")}
+
+	if value := block.Headers[activateAtHeader]; value != "" {
+		if key.activateAt, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, fmt.Errorf("invalid %s header: %w", activateAtHeader, err)
+		}
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, errors.New("only RSA and Ed25519 keys are supported")
+	}
+
+	return key, nil
+}
+
+// current returns the private key that was activated last, or nil in HMAC mode
+func (r *keyRing) current(now time.Time) (*signingKey, error) {
+	if r.dir == "" {
+		return nil, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var current *signingKey
+	for _, key := range r.keys {
+		if key.private == nil || key.activateAt.After(now) {
+			continue
+		}
+		if current == nil || key.activateAt.After(current.activateAt) ||
+			(key.activateAt.Equal(current.activateAt) && key.id > current.id) {
+			current = key
+		}
+	}
+
+	if current == nil {
+		return nil, errors.New("no JWT signing key is active yet")
+	}
+	return current, nil
+}
+
+// sign signs the claims with the current key, naming it in the kid header
+func (r *keyRing) sign(claims jwt.Claims) (string, error) {
+	key, err := r.current(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	if key == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(r.secret)
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
+}
+
+// verificationKey returns the key a token must be verified with, selected by its kid
+func (r *keyRing) verificationKey(token *jwt.Token) (interface{}, error) {
+	if r.dir == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return r.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+
+	r.mu.RLock()
+	key, ok := r.keys[kid]
+	r.mu.RUnlock()
+
+	if !ok || token.Method.Alg() != key.method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	return key.public, nil
+}
+
+// JWK is the public part of a signing key as published in the JWKS (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Curve     string `json:"crv,omitempty"` // OKP
+	X         string `json:"x,omitempty"`   // OKP
+	N         string `json:"n,omitempty"`   // RSA
+	E         string `json:"e,omitempty"`   // RSA
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// PublicKeys returns the public keys tokens may be verified with, including keys scheduled
+// to sign later. The set is empty when tokens are signed with HMAC, whose secret is never
+// published.
+func PublicKeys() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	if keys == nil {
+		return jwks
+	}
+
+	keys.mu.RLock()
+	defer keys.mu.RUnlock()
+
+	for _, key := range keys.keys {
+		jwk := JWK{Use: "sig", Algorithm: key.method.Alg(), KeyID: key.id}
+
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool { return jwks.Keys[i].KeyID < jwks.Keys[j].KeyID })
+	return jwks
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeKey writes a PEM key file named after its kid into dir
+func writeKey(t *testing.T, dir, kid, pemType string, der []byte, activateAt string) {
+	t.Helper()
+	block := &pem.Block{Type: pemType, Bytes: der}
+	if activateAt != "" {
+		block.Headers = map[string]string{activateAtHeader: activateAt}
+	}
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestKeyRing returns a key ring with an RSA key "rsa-1", an Ed25519 key "ed-1" activated
+// later, and the verify-only public key "old-1" of a retired Ed25519 key
+func newTestKeyRing(t *testing.T) (*keyRing, *rsa.PrivateKey, ed25519.PrivateKey) {
+	t.Helper()
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "rsa-1", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), "2024-01-01T00:00:00Z")
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "ed-1", "PRIVATE KEY", der, "2025-01-01T00:00:00Z")
+
+	oldPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err = x509.MarshalPKIXPublicKey(oldPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "old-1", "PUBLIC KEY", der, "")
+
+	ring := &keyRing{dir: dir, secret: []byte("secret")}
+	if err := ring.reload(); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	return ring, rsaKey, edKey
+}
+
+func TestKeyRingCurrent(t *testing.T) {
+	ring, _, _ := newTestKeyRing(t)
+
+	tests := []struct {
+		name    string
+		now     string
+		wantKid string
+		wantErr bool
+	}{
+		{"before any key is active", "2023-06-01T00:00:00Z", "", true},
+		{"first key", "2024-06-01T00:00:00Z", "rsa-1", false},
+		{"rotated key", "2025-06-01T00:00:00Z", "ed-1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, _ := time.Parse(time.RFC3339, tt.now)
+			key, err := ring.current(now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("current() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && key.id != tt.wantKid {
+				t.Errorf("current() = %s, want %s", key.id, tt.wantKid)
+			}
+		})
+	}
+}
+
+func TestKeyRingVerificationKey(t *testing.T) {
+	ring, rsaKey, edKey := newTestKeyRing(t)
+	claims := jwt.RegisteredClaims{Subject: "1"}
+
+	sign := func(method jwt.SigningMethod, kid string, key interface{}) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	// HS256 signed with the published RSA public key, the classic algorithm confusion attack
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPublicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rsaPublicDER})
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"RS256 with its kid", sign(jwt.SigningMethodRS256, "rsa-1", rsaKey), true},
+		{"EdDSA with its kid", sign(jwt.SigningMethodEdDSA, "ed-1", edKey), true},
+		{"missing kid", sign(jwt.SigningMethodRS256, "", rsaKey), false},
+		{"unknown kid", sign(jwt.SigningMethodRS256, "rsa-2", rsaKey), false},
+		{"kid of another key", sign(jwt.SigningMethodEdDSA, "old-1", edKey), false},
+		{"alg of another key type", sign(jwt.SigningMethodEdDSA, "rsa-1", edKey), false},
+		{"HS256 with the RSA public key", sign(jwt.SigningMethodHS256, "rsa-1", rsaPublicPEM), false},
+		{"HS256 with the shared secret", sign(jwt.SigningMethodHS256, "rsa-1", ring.secret), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwt.Parse(tt.token, ring.verificationKey)
+			if (err == nil) != tt.valid {
+				t.Errorf("Parse() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestKeyRingSignRoundTrip(t *testing.T) {
+	ring, _, _ := newTestKeyRing(t)
+
+	signed, err := ring.sign(jwt.RegisteredClaims{Subject: "1"})
+	if err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+
+	token, err := jwt.Parse(signed, ring.verificationKey)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if kid := token.Header["kid"]; kid != "ed-1" {
+		t.Errorf("kid = %v, want ed-1", kid)
+	}
+}
+
+func TestHMACKeyRingVerificationKey(t *testing.T) {
+	ring := &keyRing{secret: []byte("secret")}
+
+	signed, err := ring.sign(jwt.RegisteredClaims{Subject: "1"})
+	if err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+	if _, err := jwt.Parse(signed, ring.verificationKey); err != nil {
+		t.Errorf("Parse() error = %v", err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.RegisteredClaims{Subject: "1"}).SignedString(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jwt.Parse(other, ring.verificationKey); err == nil {
+		t.Error("Parse() accepted an EdDSA token in HMAC mode")
+	}
+}
+
+func TestKeyRingReloadErrors(t *testing.T) {
+	publicOnly := func(t *testing.T, dir string) {
+		public, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(public)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeKey(t, dir, "old-1", "PUBLIC KEY", der, "")
+	}
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, dir string)
+	}{
+		{"empty directory", func(t *testing.T, dir string) {}},
+		{"only public keys", publicOnly},
+		{"not PEM", func(t *testing.T, dir string) {
+			if err := os.WriteFile(filepath.Join(dir, "bad.pem"), []byte("not a key"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}},
+		{"unsupported PEM type", func(t *testing.T, dir string) {
+			writeKey(t, dir, "cert", "CERTIFICATE", []byte{1}, "")
+		}},
+		{"invalid Activate-At", func(t *testing.T, dir string) {
+			_, edKey, err := ed25519.GenerateKey(rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			der, err := x509.MarshalPKCS8PrivateKey(edKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeKey(t, dir, "ed-1", "PRIVATE KEY", der, "tomorrow")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.setup(t, dir)
+			ring := &keyRing{dir: dir}
+			if err := ring.reload(); err == nil {
+				t.Error("reload() error = nil, want an error")
+			}
+		})
+	}
+}
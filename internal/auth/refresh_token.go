@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"football-management-api/internal/config"
+	"time"
+)
+
+// NewRefreshToken generates an opaque refresh token. Only its hash is stored, the token itself
+// is given to the client once.
+func NewRefreshToken() (token, tokenHash string, expiresAt time.Time, err error) {
+	token, err = randomToken(32)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	expiresAt = time.Now().Add(time.Duration(config.GlobalConfig.JWT.RefreshTokenTTL) * time.Hour)
+	return token, HashToken(token), expiresAt, nil
+}
+
+// NewFamilyID generates the ID of a new login session
+func NewFamilyID() (string, error) {
+	return randomToken(16)
+}
+
+// HashToken returns the SHA-256 hex digest under which a refresh token is stored
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken returns n random bytes encoded as URL-safe base64
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"football-management-api/internal/config"
+	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are the JWT claims of an access token
+type Claims struct {
+	UserID   int             `json:"user_id"`
+	Username string          `json:"username"`
+	Role     models.UserRole `json:"role"`
+	TeamID   int             `json:"team_id,omitempty"`
+	FamilyID string          `json:"fid"` // refresh token family (login session) of the token
+	jwt.RegisteredClaims
+}
+
+// Actor returns the user the token was issued to
+func (c *Claims) Actor() policy.Actor {
+	return policy.Actor{
+		UserID:   c.UserID,
+		Username: c.Username,
+		Role:     c.Role,
+		TeamID:   c.TeamID,
+	}
+}
+
+// GenerateToken issues a signed access token for the user carrying their role, bound to the
+// login session familyID. The token expires after JWTConfig.AccessTokenTTL minutes.
+func GenerateToken(user *models.User, familyID string) (string, time.Time, error) {
+	jwtConfig := config.GlobalConfig.JWT
+
+	jti, err := randomToken(16)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(jwtConfig.AccessTokenTTL) * time.Minute)
+
+	claims := Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		TeamID:   int(user.TeamID.Int64),
+		FamilyID: familyID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.Itoa(user.ID),
+			Issuer:    config.GlobalConfig.App.Name,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := keys.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// ParseToken validates the signature and expiry of an access token and returns its claims.
+// The token must be signed with a key of the key ring, named by its kid header.
+func ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, keys.verificationKey,
+		jwt.WithValidMethods([]string{"HS256", "RS256", "EdDSA"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	return claims, nil
+}
@@ -16,6 +16,8 @@ type Config struct {
 	JWT      JWTConfig
 	App      AppConfig
 	Log      LogConfig
+	Cache    CacheConfig
+	Trash    TrashConfig
 }
 
 // ServerConfig holds server configuration
@@ -35,10 +37,17 @@ type DatabaseConfig struct {
 	MaxOpenConns int
 }
 
-// JWTConfig holds JWT configuration
+// defaultJWTSecret is the development fallback for JWT_SECRET, refused in production
+const defaultJWTSecret = "your-secret-key"
+
+// JWTConfig holds JWT configuration. Tokens are signed with the asymmetric keys in KeysDir
+// when set, otherwise with the HMAC Secret.
 type JWTConfig struct {
-	Secret     string
-	Expiration int
+	Secret             string
+	KeysDir            string
+	KeysReloadInterval int // minutes
+	AccessTokenTTL     int // minutes
+	RefreshTokenTTL    int // hours
 }
 
 // AppConfig holds application configuration
@@ -54,6 +63,17 @@ type LogConfig struct {
 	File  string
 }
 
+// TrashConfig holds retention of soft-deleted records
+type TrashConfig struct {
+	RetentionDays int // days a deleted record stays restorable
+	PurgeInterval int // hours between automatic purges, 0 disables them
+}
+
+// CacheConfig holds HTTP caching configuration
+type CacheConfig struct {
+	ReportMaxAge int // seconds
+}
+
 var GlobalConfig *Config
 
 // LoadConfig loads configuration from environment variables
@@ -65,7 +85,12 @@ func LoadConfig() (*Config, error) {
 
 	maxIdleConns, _ := strconv.Atoi(getEnv("DB_MAX_IDLE_CONNS", "10"))
 	maxOpenConns, _ := strconv.Atoi(getEnv("DB_MAX_OPEN_CONNS", "100"))
-	jwtExpiration, _ := strconv.Atoi(getEnv("JWT_EXPIRATION", "24"))
+	accessTokenTTL, _ := strconv.Atoi(getEnv("JWT_ACCESS_TOKEN_TTL", "15"))
+	refreshTokenTTL, _ := strconv.Atoi(getEnv("JWT_REFRESH_TOKEN_TTL", "720"))
+	keysReloadInterval, _ := strconv.Atoi(getEnv("JWT_KEYS_RELOAD_INTERVAL", "5"))
+	reportMaxAge, _ := strconv.Atoi(getEnv("REPORT_CACHE_MAX_AGE", "60"))
+	trashRetentionDays, _ := strconv.Atoi(getEnv("TRASH_RETENTION_DAYS", "30"))
+	trashPurgeInterval, _ := strconv.Atoi(getEnv("TRASH_PURGE_INTERVAL", "24"))
 
 	config := &Config{
 		Server: ServerConfig{
@@ -82,8 +107,11 @@ func LoadConfig() (*Config, error) {
 			MaxOpenConns: maxOpenConns,
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your-secret-key"),
-			Expiration: jwtExpiration,
+			Secret:             getEnv("JWT_SECRET", defaultJWTSecret),
+			KeysDir:            getEnv("JWT_KEYS_DIR", ""),
+			KeysReloadInterval: keysReloadInterval,
+			AccessTokenTTL:     accessTokenTTL,
+			RefreshTokenTTL:    refreshTokenTTL,
 		},
 		App: AppConfig{
 			Env:     getEnv("APP_ENV", "development"),
@@ -94,6 +122,18 @@ func LoadConfig() (*Config, error) {
 			Level: getEnv("LOG_LEVEL", "info"),
 			File:  getEnv("LOG_FILE", "logs/app.log"),
 		},
+		Cache: CacheConfig{
+			ReportMaxAge: reportMaxAge,
+		},
+		Trash: TrashConfig{
+			RetentionDays: trashRetentionDays,
+			PurgeInterval: trashPurgeInterval,
+		},
+	}
+
+	// Anyone knowing the default secret could sign tokens for any user
+	if config.IsProduction() && config.JWT.KeysDir == "" && config.JWT.Secret == defaultJWTSecret {
+		return nil, fmt.Errorf("JWT_KEYS_DIR or a non-default JWT_SECRET is required in production")
 	}
 
 	GlobalConfig = config
@@ -11,6 +11,7 @@ const (
 // Match statuses
 const (
 	MatchStatusScheduled = "Scheduled"
+	MatchStatusLive      = "Live"
 	MatchStatusCompleted = "Completed"
 	MatchStatusCancelled = "Cancelled"
 )
@@ -22,6 +23,32 @@ const (
 	MatchResultDraw    = "Draw"
 )
 
+// Match periods relative to today
+const (
+	PeriodUpcoming = "upcoming"
+	PeriodPast     = "past"
+)
+
+// League points per result
+const (
+	PointsWin  = 3
+	PointsDraw = 1
+	PointsLoss = 0
+)
+
+// Form guide results from a team's perspective
+const (
+	FormWin  = "W"
+	FormDraw = "D"
+	FormLoss = "L"
+)
+
+// Match venues from a team's perspective
+const (
+	VenueHome = "home"
+	VenueAway = "away"
+)
+
 // ValidPlayerPositions returns all valid player positions
 func ValidPlayerPositions() []string {
 	return []string{
@@ -32,11 +59,169 @@ func ValidPlayerPositions() []string {
 	}
 }
 
+// DefensivePositions returns positions that are credited with clean sheets
+func DefensivePositions() []string {
+	return []string{
+		PositionPenjagaGawang,
+		PositionBertahan,
+	}
+}
+
 // ValidMatchStatuses returns all valid match statuses
 func ValidMatchStatuses() []string {
 	return []string{
 		MatchStatusScheduled,
+		MatchStatusLive,
 		MatchStatusCompleted,
 		MatchStatusCancelled,
 	}
 }
+
+// User roles
+const (
+	RoleAdmin         = "admin"
+	RoleTeamManager   = "team_manager"
+	RoleMatchOfficial = "match_official"
+	RoleViewer        = "viewer"
+)
+
+// ValidRoles returns all valid user roles
+func ValidRoles() []string {
+	return []string{
+		RoleAdmin,
+		RoleTeamManager,
+		RoleMatchOfficial,
+		RoleViewer,
+	}
+}
+
+// Audit log entities
+const (
+	AuditEntityTeam   = "team"
+	AuditEntityPlayer = "player"
+	AuditEntityMatch  = "match"
+	AuditEntityGoal   = "goal"
+)
+
+// AuditEntities returns all entities recorded in the audit log
+func AuditEntities() []string {
+	return []string{AuditEntityTeam, AuditEntityPlayer, AuditEntityMatch, AuditEntityGoal}
+}
+
+// Trash entities, named like their tables and routes
+const (
+	TrashTeams   = "teams"
+	TrashPlayers = "players"
+	TrashMatches = "matches"
+	TrashGoals   = "goals"
+)
+
+// TrashEntities returns all entities with a trash, in the order they are purged: records
+// go before the records they reference
+func TrashEntities() []string {
+	return []string{TrashGoals, TrashMatches, TrashPlayers, TrashTeams}
+}
+
+// Team delete policies, selected with the policy query parameter of DELETE /teams/:id
+const (
+	// TeamDeleteRestrict deletes the team only when no active player or match references it
+	TeamDeleteRestrict = "restrict"
+	// TeamDeleteCascade deletes the team together with its players, but never its matches
+	TeamDeleteCascade = "cascade"
+	// TeamDeleteArchive keeps the team and its history, and blocks new players and matches
+	TeamDeleteArchive = "archive"
+)
+
+// TeamDeletePolicies returns all team delete policies
+func TeamDeletePolicies() []string {
+	return []string{TeamDeleteRestrict, TeamDeleteCascade, TeamDeleteArchive}
+}
+
+// API key scopes
+const (
+	ScopeReadReports  = "read:reports"
+	ScopeWriteTeams   = "write:teams"
+	ScopeWritePlayers = "write:players"
+	ScopeWriteMatches = "write:matches"
+	ScopeWriteResults = "write:results"
+	ScopeWriteGoals   = "write:goals"
+)
+
+// ValidScopes returns all API key scopes
+func ValidScopes() []string {
+	return []string{
+		ScopeReadReports,
+		ScopeWriteTeams,
+		ScopeWritePlayers,
+		ScopeWriteMatches,
+		ScopeWriteResults,
+		ScopeWriteGoals,
+	}
+}
+
+// TeamSortFields returns the fields the team list can be sorted by
+func TeamSortFields() []string {
+	return []string{"name", "city", "founded_year", "created_at"}
+}
+
+// PlayerSortFields returns the fields the player list can be sorted by
+func PlayerSortFields() []string {
+	return []string{"name", "height", "weight", "position", "jersey_number", "team", "created_at"}
+}
+
+// MatchSortFields returns the fields the match list can be sorted by
+func MatchSortFields() []string {
+	return []string{"match_date", "status", "home_team", "away_team", "created_at"}
+}
+
+// GoalSortFields returns the fields the goal list can be sorted by
+func GoalSortFields() []string {
+	return []string{"goal_time", "player", "created_at"}
+}
+
+// Search result types
+const (
+	SearchTypeTeam   = "team"
+	SearchTypePlayer = "player"
+	SearchTypeMatch  = "match"
+)
+
+// Search limits
+const (
+	SearchMinQueryLength = 2
+	SearchDefaultLimit   = 20
+	SearchMaxLimit       = 50
+)
+
+// ValidSearchTypes returns all searchable result types
+func ValidSearchTypes() []string {
+	return []string{
+		SearchTypeTeam,
+		SearchTypePlayer,
+		SearchTypeMatch,
+	}
+}
+
+// Relations that can be embedded with the include parameter
+const (
+	IncludePlayers      = "players"
+	IncludeMatches      = "matches"
+	IncludeMatchesGoals = "matches.goals"
+	IncludeGoals        = "goals"
+	IncludeTeam         = "team"
+)
+
+// TeamIncludes returns the relations that can be embedded in teams
+func TeamIncludes() []string {
+	return []string{IncludePlayers, IncludeMatches, IncludeMatchesGoals}
+}
+
+// PlayerIncludes returns the relations that can be embedded in players
+func PlayerIncludes() []string {
+	return []string{IncludeTeam, IncludeGoals}
+}
+
+// MatchIncludes returns the relations that can be embedded in matches
+func MatchIncludes() []string {
+	return []string{IncludeGoals}
+}
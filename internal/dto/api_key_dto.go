@@ -0,0 +1,29 @@
+package dto
+
+// CreateAPIKeyRequest represents request to issue an API key. A key without
+// expires_in_days never expires.
+type CreateAPIKeyRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1"`
+	ExpiresInDays int      `json:"expires_in_days" binding:"omitempty,min=1,max=3650"`
+}
+
+// APIKeyResponse represents API key data in response. The key itself is never returned.
+type APIKeyResponse struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  *string  `json:"expires_at"`
+	LastUsedAt *string  `json:"last_used_at"`
+	RevokedAt  *string  `json:"revoked_at"`
+	CreatedBy  *int     `json:"created_by"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// APIKeyCreatedResponse represents a newly issued API key. This is the only response that
+// contains the key.
+type APIKeyCreatedResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+// AuditListQuery represents filters for the audit log
+type AuditListQuery struct {
+	Entity   string `form:"entity"`
+	EntityID int    `form:"entity_id"`
+	Actor    string `form:"actor"`
+	DateFrom string `form:"date_from"`
+	DateTo   string `form:"date_to"`
+}
+
+// FieldChange represents the value of a field before and after a change
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditLogResponse represents an audit entry in response
+type AuditLogResponse struct {
+	ID            int64                  `json:"id"`
+	Entity        string                 `json:"entity"`
+	EntityID      int                    `json:"entity_id"`
+	Action        string                 `json:"action"`
+	ActorUserID   *int                   `json:"actor_user_id"`
+	ActorAPIKeyID *int                   `json:"actor_api_key_id"`
+	ActorName     string                 `json:"actor_name"`
+	RequestID     string                 `json:"request_id"`
+	Changes       map[string]FieldChange `json:"changes"`
+	CreatedAt     string                 `json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+// RegisterRequest represents request to create a user account
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=50"`
+	Email    string `json:"email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required,min=8,max=72"`
+}
+
+// LoginRequest represents request to sign in
+type LoginRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshTokenRequest represents request to rotate a refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// UserResponse represents user data in response
+type UserResponse struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	TeamID    *int   `json:"team_id,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// UpdateUserRoleRequest represents request to assign a role to a user. team_id is required
+// for team managers only.
+type UpdateUserRoleRequest struct {
+	Role   string `json:"role" binding:"required"`
+	TeamID int    `json:"team_id"`
+}
+
+// TokenResponse represents an issued access token and the refresh token to renew it
+type TokenResponse struct {
+	AccessToken           string       `json:"access_token"`
+	TokenType             string       `json:"token_type"`
+	ExpiresIn             int          `json:"expires_in"` // seconds
+	ExpiresAt             string       `json:"expires_at"`
+	RefreshToken          string       `json:"refresh_token"`
+	RefreshTokenExpiresAt string       `json:"refresh_token_expires_at"`
+	User                  UserResponse `json:"user"`
+}
@@ -7,13 +7,32 @@ type CreateGoalRequest struct {
 	GoalTime string `json:"goal_time" binding:"required"`
 }
 
+// UpdateGoalRequest represents request to correct a recorded goal
+type UpdateGoalRequest struct {
+	PlayerID int    `json:"player_id" binding:"required"`
+	GoalTime string `json:"goal_time" binding:"required"`
+}
+
+// GoalListQuery represents filters, search and sorting for the goal list
+type GoalListQuery struct {
+	Search   string  `form:"q"`
+	Sort     string  `form:"sort"`
+	MatchID  int     `form:"match_id"`
+	PlayerID int     `form:"player_id"`
+	TeamID   int     `form:"team_id"`
+	Cursor   *string `form:"cursor"`
+}
+
 // GoalResponse represents goal data in response
 type GoalResponse struct {
 	ID         int    `json:"id"`
 	MatchID    int    `json:"match_id"`
 	PlayerID   int    `json:"player_id"`
 	PlayerName string `json:"player_name,omitempty"`
+	TeamID     int    `json:"team_id"`
 	TeamName   string `json:"team_name,omitempty"`
 	GoalTime   string `json:"goal_time"`
+	Version    int    `json:"version,omitempty"`
 	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
 }
@@ -8,13 +8,13 @@ type CreateMatchRequest struct {
 	AwayTeamID int    `json:"away_team_id" binding:"required"`
 }
 
-// UpdateMatchRequest represents request to update a match
+// UpdateMatchRequest represents request to replace a match's schedule, teams and status
 type UpdateMatchRequest struct {
-	MatchDate  string `json:"match_date"`
-	MatchTime  string `json:"match_time"`
-	HomeTeamID int    `json:"home_team_id"`
-	AwayTeamID int    `json:"away_team_id"`
-	Status     string `json:"status"`
+	MatchDate  string `json:"match_date" binding:"required"`
+	MatchTime  string `json:"match_time" binding:"required"`
+	HomeTeamID int    `json:"home_team_id" binding:"required"`
+	AwayTeamID int    `json:"away_team_id" binding:"required"`
+	Status     string `json:"status" binding:"required"`
 }
 
 // UpdateMatchResultRequest represents request to update match result
@@ -30,6 +30,39 @@ type GoalInputDetail struct {
 	GoalTime string `json:"goal_time" binding:"required"`
 }
 
+// MatchListQuery represents filters, search, sorting and includes for the match list
+type MatchListQuery struct {
+	Include  string  `form:"include"`
+	Search   string  `form:"q"`
+	Sort     string  `form:"sort"`
+	Status   string  `form:"status"`
+	DateFrom string  `form:"date_from"`
+	DateTo   string  `form:"date_to"`
+	Cursor   *string `form:"cursor"`
+}
+
+// TeamMatchesQuery represents query parameters for a team's fixtures and results
+type TeamMatchesQuery struct {
+	Upcoming bool   `form:"upcoming"`
+	Past     bool   `form:"past"`
+	Home     bool   `form:"home"`
+	Away     bool   `form:"away"`
+	Status   string `form:"status"`
+	DateFrom string `form:"date_from"`
+	DateTo   string `form:"date_to"`
+}
+
+// TeamMatchResponse represents a match from a single team's perspective
+type TeamMatchResponse struct {
+	MatchResponse
+	Venue         string `json:"venue"`
+	OpponentID    int    `json:"opponent_id"`
+	OpponentName  string `json:"opponent_name"`
+	GoalsScored   *int   `json:"goals_scored"`
+	GoalsConceded *int   `json:"goals_conceded"`
+	Result        string `json:"result,omitempty"`
+}
+
 // MatchResponse represents match data in response
 type MatchResponse struct {
 	ID           int    `json:"id"`
@@ -42,6 +75,10 @@ type MatchResponse struct {
 	HomeScore    *int   `json:"home_score"`
 	AwayScore    *int   `json:"away_score"`
 	Status       string `json:"status"`
+	Version      int    `json:"version,omitempty"`
 	CreatedAt    string `json:"created_at"`
 	UpdatedAt    string `json:"updated_at"`
+
+	// Relations embedded with the include parameter
+	Goals []GoalResponse `json:"goals,omitempty"`
 }
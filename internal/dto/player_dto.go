@@ -10,14 +10,39 @@ type CreatePlayerRequest struct {
 	JerseyNumber int     `json:"jersey_number" binding:"required,min=1,max=99"`
 }
 
-// UpdatePlayerRequest represents request to update a player
+// UpdatePlayerRequest represents request to replace a player
 type UpdatePlayerRequest struct {
-	TeamID       int     `json:"team_id"`
-	Name         string  `json:"name"`
-	Height       float64 `json:"height" binding:"omitempty,min=100,max=250"`
-	Weight       float64 `json:"weight" binding:"omitempty,min=30,max=200"`
-	Position     string  `json:"position"`
-	JerseyNumber int     `json:"jersey_number" binding:"omitempty,min=1,max=99"`
+	TeamID       int     `json:"team_id" binding:"required"`
+	Name         string  `json:"name" binding:"required"`
+	Height       float64 `json:"height" binding:"required,min=100,max=250"`
+	Weight       float64 `json:"weight" binding:"required,min=30,max=200"`
+	Position     string  `json:"position" binding:"required"`
+	JerseyNumber int     `json:"jersey_number" binding:"required,min=1,max=99"`
+}
+
+// PlayerListQuery represents filters, search, sorting and includes for the player list
+type PlayerListQuery struct {
+	Include   string  `form:"include"`
+	Search    string  `form:"q"`
+	Sort      string  `form:"sort"`
+	TeamID    int     `form:"team_id"`
+	Position  string  `form:"position"`
+	HeightMin float64 `form:"height_min"`
+	HeightMax float64 `form:"height_max"`
+	Cursor    *string `form:"cursor"`
+}
+
+// PlayerMatchesQuery represents query parameters for a player's match history
+type PlayerMatchesQuery struct {
+	DateFrom string `form:"date_from"`
+	DateTo   string `form:"date_to"`
+}
+
+// PlayerMatchResponse represents a match in a player's history with the player's goals
+type PlayerMatchResponse struct {
+	MatchResponse
+	GoalsScored int      `json:"goals_scored"`
+	GoalMinutes []string `json:"goal_minutes"`
 }
 
 // PlayerResponse represents player data in response
@@ -30,6 +55,11 @@ type PlayerResponse struct {
 	Weight       float64 `json:"weight"`
 	Position     string  `json:"position"`
 	JerseyNumber int     `json:"jersey_number"`
+	Version      int     `json:"version,omitempty"`
 	CreatedAt    string  `json:"created_at"`
 	UpdatedAt    string  `json:"updated_at"`
+
+	// Relations embedded with the include parameter
+	Team  *TeamResponse  `json:"team,omitempty"`
+	Goals []GoalResponse `json:"goals,omitempty"`
 }
@@ -1,19 +1,33 @@
 package dto
 
-// Response represents a standard API response
+// Response represents a standard API response. Errors carry a stable machine-readable code,
+// and validation errors list every invalid field in Errors.
 type Response struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success bool         `json:"success"`
+	Message string       `json:"message"`
+	Data    interface{}  `json:"data,omitempty"`
+	Code    string       `json:"code,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Errors  []FieldError `json:"errors,omitempty"`
 }
 
-// PaginationMeta represents pagination metadata
+// FieldError represents a failed validation rule of a request field
+type FieldError struct {
+	Field   string                 `json:"field"`
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+}
+
+// PaginationMeta represents pagination metadata. With cursor pagination only per_page and
+// the cursors are set, the total is not counted.
 type PaginationMeta struct {
-	CurrentPage int   `json:"current_page"`
-	PerPage     int   `json:"per_page"`
-	Total       int64 `json:"total"`
-	TotalPages  int   `json:"total_pages"`
+	CurrentPage int    `json:"current_page"`
+	PerPage     int    `json:"per_page"`
+	Total       int64  `json:"total"`
+	TotalPages  int    `json:"total_pages"`
+	NextCursor  string `json:"next_cursor,omitempty"`
+	PrevCursor  string `json:"prev_cursor,omitempty"`
 }
 
 // PaginatedResponse represents a paginated API response
@@ -34,10 +48,11 @@ func SuccessResponse(message string, data interface{}) Response {
 }
 
 // ErrorResponse creates an error response
-func ErrorResponse(message string, err string) Response {
+func ErrorResponse(code, message, err string) Response {
 	return Response{
 		Success: false,
 		Message: message,
+		Code:    code,
 		Error:   err,
 	}
 }
@@ -0,0 +1,8 @@
+package dto
+
+// SearchQuery represents query parameters for the global search
+type SearchQuery struct {
+	Q     string `form:"q" binding:"required"`
+	Type  string `form:"type"`
+	Limit int    `form:"limit"`
+}
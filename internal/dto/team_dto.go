@@ -9,23 +9,66 @@ type CreateTeamRequest struct {
 	HomeCity    string `json:"home_city" binding:"required"`
 }
 
-// UpdateTeamRequest represents request to update a team
+// UpdateTeamRequest represents request to replace a team. Every field is written, so an
+// omitted logo_url clears the logo.
 type UpdateTeamRequest struct {
-	Name        string `json:"name"`
+	Name        string `json:"name" binding:"required"`
 	LogoURL     string `json:"logo_url"`
-	FoundedYear int    `json:"founded_year" binding:"omitempty,min=1800,max=2100"`
-	HomeAddress string `json:"home_address"`
-	HomeCity    string `json:"home_city"`
+	FoundedYear int    `json:"founded_year" binding:"required,min=1800,max=2100"`
+	HomeAddress string `json:"home_address" binding:"required"`
+	HomeCity    string `json:"home_city" binding:"required"`
+}
+
+// TeamListQuery represents filters, search, sorting and includes for the team list
+type TeamListQuery struct {
+	Include     string  `form:"include"`
+	Search      string  `form:"q"`
+	Sort        string  `form:"sort"`
+	City        string  `form:"city"`
+	FoundedFrom int     `form:"founded_from"`
+	FoundedTo   int     `form:"founded_to"`
+	Archived    *bool   `form:"archived"`
+	Cursor      *string `form:"cursor"`
+}
+
+// TeamDeleteQuery represents the policy of a team delete and whether it is only previewed
+type TeamDeleteQuery struct {
+	Policy string `form:"policy"`
+	DryRun bool   `form:"dry_run"`
+}
+
+// TeamDeletePreview describes what deleting a team with a policy does. Players lists the active
+// players of the team and Matches its active matches.
+type TeamDeletePreview struct {
+	TeamID  int                     `json:"team_id"`
+	Policy  string                  `json:"policy"`
+	Allowed bool                    `json:"allowed"`
+	Reason  string                  `json:"reason,omitempty"`
+	Action  string                  `json:"action"`
+	Players []TeamDependentResponse `json:"players"`
+	Matches []TeamDependentResponse `json:"matches"`
+}
+
+// TeamDependentResponse represents an active record that references a team
+type TeamDependentResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
 }
 
 // TeamResponse represents team data in response
 type TeamResponse struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	LogoURL     string `json:"logo_url,omitempty"`
-	FoundedYear int    `json:"founded_year"`
-	HomeAddress string `json:"home_address"`
-	HomeCity    string `json:"home_city"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	LogoURL     string  `json:"logo_url,omitempty"`
+	FoundedYear int     `json:"founded_year"`
+	HomeAddress string  `json:"home_address"`
+	HomeCity    string  `json:"home_city"`
+	Version     int     `json:"version,omitempty"`
+	ArchivedAt  *string `json:"archived_at,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+
+	// Relations embedded with the include parameter
+	Players []PlayerResponse `json:"players,omitempty"`
+	Matches []MatchResponse  `json:"matches,omitempty"`
 }
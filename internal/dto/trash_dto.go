@@ -0,0 +1,14 @@
+package dto
+
+// TrashItemResponse represents a deleted record in response
+type TrashItemResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	DeletedAt string `json:"deleted_at"`
+	PurgeAt   string `json:"purge_at"` // when the record is permanently deleted
+}
+
+// PurgeTrashResponse represents the number of records permanently deleted per entity
+type PurgeTrashResponse struct {
+	Purged map[string]int `json:"purged"`
+}
@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"football-management-api/internal/policy"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentActor returns the authenticated user set by the auth middleware, tagged with the
+// request ID. Requests without a valid token get an empty actor that no policy allows to write.
+func currentActor(c *gin.Context) policy.Actor {
+	value, _ := c.Get("actor")
+	actor, _ := value.(policy.Actor)
+	actor.RequestID = c.GetString("request_id")
+	return actor
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"football-management-api/internal/dto"
+	"football-management-api/internal/service"
+	"football-management-api/internal/utils"
+	"football-management-api/internal/validator"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// Create handles issuing an API key
+// @Summary Issue an API key (admin only)
+// @Description The key is only returned in this response; store it securely
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param api_key body dto.CreateAPIKeyRequest true "API key data"
+// @Success 201 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /api-keys [post]
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateCreateAPIKey(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	apiKey, err := h.apiKeyService.Create(currentActor(c), req)
+	if err != nil {
+		utils.SendError(c, "Gagal membuat API key", err)
+		return
+	}
+
+	utils.SendCreated(c, "API key berhasil dibuat, simpan key ini karena tidak akan ditampilkan lagi", apiKey)
+}
+
+// GetAll handles getting all API keys
+// @Summary Get all API keys (admin only)
+// @Tags api-keys
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /api-keys [get]
+func (h *APIKeyHandler) GetAll(c *gin.Context) {
+	apiKeys, err := h.apiKeyService.GetAll(currentActor(c))
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil data API key", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Data API key berhasil diambil", apiKeys)
+}
+
+// Revoke handles revoking an API key
+// @Summary Revoke an API key (admin only)
+// @Tags api-keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(currentActor(c), id); err != nil {
+		utils.SendError(c, "Gagal mencabut API key", err)
+		return
+	}
+
+	utils.SendSuccess(c, "API key berhasil dicabut", nil)
+}
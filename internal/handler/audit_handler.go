@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"football-management-api/internal/dto"
+	"football-management-api/internal/service"
+	"football-management-api/internal/utils"
+	"football-management-api/internal/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	auditService service.AuditService
+}
+
+func NewAuditHandler(auditService service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// GetAll handles getting the audit log
+// @Summary Get the audit log of data changes (admin only)
+// @Tags audit
+// @Produce json
+// @Param entity query string false "Filter by entity (team, player, match, goal)"
+// @Param entity_id query int false "Filter by entity ID, requires entity"
+// @Param actor query string false "Filter by username or API key name"
+// @Param date_from query string false "Changes on or after this date (YYYY-MM-DD)"
+// @Param date_to query string false "Changes on or before this date (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /audit [get]
+func (h *AuditHandler) GetAll(c *gin.Context) {
+	var query dto.AuditListQuery
+	bindErr := c.ShouldBindQuery(&query)
+	if err := utils.ValidationError(bindErr, validator.ValidateAuditListQuery(query)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	page, limit := utils.GetPaginationParams(c)
+
+	entries, meta, err := h.auditService.GetAll(currentActor(c), query, page, limit)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil audit log", err)
+		return
+	}
+
+	utils.SendPaginated(c, "Audit log berhasil diambil", entries, meta)
+}
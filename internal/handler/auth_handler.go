@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"football-management-api/internal/auth"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/service"
+	"football-management-api/internal/utils"
+	"football-management-api/internal/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	authService service.AuthService
+}
+
+func NewAuthHandler(authService service.AuthService) *AuthHandler {
+	return &AuthHandler{authService: authService}
+}
+
+// Register handles creating a user account
+// @Summary Register a user
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body dto.RegisterRequest true "Account data"
+// @Success 201 {object} dto.Response
+// @Router /auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req dto.RegisterRequest
+
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateRegister(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	user, err := h.authService.Register(req)
+	if err != nil {
+		utils.SendError(c, "Gagal mendaftarkan pengguna", err)
+		return
+	}
+
+	utils.SendCreated(c, "Pengguna berhasil didaftarkan", user)
+}
+
+// Login handles signing in and issuing an access token
+// @Summary Login
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.LoginRequest true "Username and password"
+// @Success 200 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req dto.LoginRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, "Data tidak valid", utils.ValidationError(err, nil))
+		return
+	}
+
+	token, err := h.authService.Login(req)
+	if err != nil {
+		utils.SendError(c, "Login gagal", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Login berhasil", token)
+}
+
+// Refresh handles rotating a refresh token into a new access and refresh token
+// @Summary Refresh tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendError(c, "Data tidak valid", utils.ValidationError(err, nil))
+		return
+	}
+
+	token, err := h.authService.Refresh(req)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui token", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Token berhasil diperbarui", token)
+}
+
+// Logout handles ending the session of the current access token
+// @Summary Logout
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	// API keys have no session to end
+	value, _ := c.Get("claims")
+	claims, ok := value.(*auth.Claims)
+	if !ok {
+		utils.SendUnauthorized(c, "Unauthorized", "token tidak ditemukan")
+		return
+	}
+
+	if err := h.authService.Logout(claims); err != nil {
+		utils.SendError(c, "Gagal logout", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Logout berhasil", nil)
+}
+
+// Me handles getting the authenticated user
+// @Summary Get the authenticated user
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Router /auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	user, err := h.authService.GetUser(c.GetInt("user_id"))
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil data pengguna", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Pengguna ditemukan", user)
+}
@@ -25,27 +25,82 @@ func NewGoalHandler(goalService service.GoalService) *GoalHandler {
 // @Produce json
 // @Param goal body dto.CreateGoalRequest true "Goal data"
 // @Success 201 {object} dto.Response
+// @Failure 403 {object} dto.Response
 // @Router /goals [post]
 func (h *GoalHandler) Create(c *gin.Context) {
 	var req dto.CreateGoalRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendBadRequest(c, "Data tidak valid", utils.FormatValidationError(err))
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateCreateGoal(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
 		return
 	}
 
-	if err := validator.ValidateCreateGoal(req); err != nil {
-		utils.SendBadRequest(c, "Validasi gagal", err.Error())
+	goal, err := h.goalService.Create(currentActor(c), req)
+	if err != nil {
+		utils.SendError(c, "Gagal membuat data gol", err)
+		return
+	}
+
+	utils.SetETag(c, goal.Version)
+	utils.SendCreated(c, "Data gol berhasil dibuat", goal)
+}
+
+// GetAll handles getting all goals with filters, search, sorting and pagination
+// @Summary Get all goals
+// @Tags goals
+// @Produce json
+// @Param q query string false "Search by scorer name"
+// @Param sort query string false "Sort fields, e.g. -created_at,player"
+// @Param match_id query int false "Filter by match ID"
+// @Param player_id query int false "Filter by player ID"
+// @Param team_id query int false "Filter by scorer's team ID"
+// @Param cursor query string false "Cursor for keyset pagination, empty for the first page"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.PaginatedResponse
+// @Router /goals [get]
+func (h *GoalHandler) GetAll(c *gin.Context) {
+	var query dto.GoalListQuery
+	bindErr := c.ShouldBindQuery(&query)
+	if err := utils.ValidationError(bindErr, validator.ValidateGoalListQuery(query)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
 		return
 	}
 
-	goal, err := h.goalService.Create(req)
+	page, limit := utils.GetPaginationParams(c)
+
+	goals, meta, err := h.goalService.GetAll(query, page, limit)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal membuat data gol", err.Error())
+		utils.SendError(c, "Gagal mengambil data gol", err)
 		return
 	}
 
-	utils.SendCreated(c, "Data gol berhasil dibuat", goal)
+	utils.SendPaginated(c, "Data gol berhasil diambil", goals, meta)
+}
+
+// GetByID handles getting a goal by ID
+// @Summary Get goal by ID
+// @Tags goals
+// @Produce json
+// @Param id path int true "Goal ID"
+// @Success 200 {object} dto.Response
+// @Router /goals/{id} [get]
+func (h *GoalHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	goal, err := h.goalService.GetByID(id)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil data gol", err)
+		return
+	}
+
+	utils.SetETag(c, goal.Version)
+	utils.SendSuccess(c, "Data gol ditemukan", goal)
 }
 
 // GetByMatchID handles getting goals by match ID
@@ -64,19 +119,65 @@ func (h *GoalHandler) GetByMatchID(c *gin.Context) {
 
 	goals, err := h.goalService.GetByMatchID(matchID)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal mengambil data gol", err.Error())
+		utils.SendError(c, "Gagal mengambil data gol", err)
 		return
 	}
 
 	utils.SendSuccess(c, "Data gol berhasil diambil", goals)
 }
 
+// Update handles correcting a goal
+// @Summary Update a goal
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param id path int true "Goal ID"
+// @Param goal body dto.UpdateGoalRequest true "Goal data"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
+// @Router /goals/{id} [put]
+func (h *GoalHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateGoalRequest
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateUpdateGoal(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	goal, err := h.goalService.Update(currentActor(c), id, version, req)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui data gol", err)
+		return
+	}
+
+	utils.SetETag(c, goal.Version)
+	utils.SendSuccess(c, "Data gol berhasil diperbarui", goal)
+}
+
 // Delete handles deleting a goal
 // @Summary Delete a goal
 // @Tags goals
 // @Produce json
 // @Param id path int true "Goal ID"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
 // @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
 // @Router /goals/{id} [delete]
 func (h *GoalHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -85,9 +186,14 @@ func (h *GoalHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.goalService.Delete(id)
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	err = h.goalService.Delete(currentActor(c), id, version)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal menghapus data gol", err.Error())
+		utils.SendError(c, "Gagal menghapus data gol", err)
 		return
 	}
 
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"football-management-api/internal/auth"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct{}
+
+func NewJWKSHandler() *JWKSHandler {
+	return &JWKSHandler{}
+}
+
+// GetJWKS handles publishing the public keys access tokens are signed with
+// @Summary Get the JSON Web Key Set
+// @Description Public keys for verifying access tokens, selected by the token's kid header. Returned as a plain JWKS document, not wrapped in the API response format.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	// Verifiers cache the set; keys are published before they sign, so a short max-age is enough
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, auth.PublicKeys())
+}
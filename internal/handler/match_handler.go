@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/service"
 	"football-management-api/internal/utils"
@@ -25,26 +26,24 @@ func NewMatchHandler(matchService service.MatchService) *MatchHandler {
 // @Produce json
 // @Param match body dto.CreateMatchRequest true "Match data"
 // @Success 201 {object} dto.Response
+// @Failure 403 {object} dto.Response
 // @Router /matches [post]
 func (h *MatchHandler) Create(c *gin.Context) {
 	var req dto.CreateMatchRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendBadRequest(c, "Data tidak valid", utils.FormatValidationError(err))
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateCreateMatch(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
 		return
 	}
 
-	if err := validator.ValidateCreateMatch(req); err != nil {
-		utils.SendBadRequest(c, "Validasi gagal", err.Error())
-		return
-	}
-
-	match, err := h.matchService.Create(req)
+	match, err := h.matchService.Create(currentActor(c), req)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal membuat pertandingan", err.Error())
+		utils.SendError(c, "Gagal membuat pertandingan", err)
 		return
 	}
 
+	utils.SetETag(c, match.Version)
 	utils.SendCreated(c, "Pertandingan berhasil dibuat", match)
 }
 
@@ -53,6 +52,7 @@ func (h *MatchHandler) Create(c *gin.Context) {
 // @Tags matches
 // @Produce json
 // @Param id path int true "Match ID"
+// @Param include query string false "Relations to embed: goals"
 // @Success 200 {object} dto.Response
 // @Router /matches/{id} [get]
 func (h *MatchHandler) GetByID(c *gin.Context) {
@@ -62,43 +62,109 @@ func (h *MatchHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	match, err := h.matchService.GetByID(id)
+	include := c.Query("include")
+	if err := validator.ValidateIncludes(include, config.MatchIncludes()); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	match, err := h.matchService.GetByID(id, utils.ParseIncludes(include))
 	if err != nil {
-		utils.SendNotFound(c, "Pertandingan tidak ditemukan", err.Error())
+		utils.SendError(c, "Gagal mengambil data pertandingan", err)
 		return
 	}
 
+	utils.SetETag(c, match.Version)
 	utils.SendSuccess(c, "Pertandingan ditemukan", match)
 }
 
-// GetAll handles getting all matches with pagination
+// GetAll handles getting all matches with filters, search, sorting and pagination
 // @Summary Get all matches
 // @Tags matches
 // @Produce json
+// @Param q query string false "Search by home or away team name"
+// @Param sort query string false "Sort fields, e.g. -match_date,status"
+// @Param status query string false "Filter by status"
+// @Param date_from query string false "Start date (YYYY-MM-DD)"
+// @Param date_to query string false "End date (YYYY-MM-DD)"
+// @Param cursor query string false "Cursor for keyset pagination, empty for the first page"
+// @Param include query string false "Relations to embed: goals"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} dto.PaginatedResponse
 // @Router /matches [get]
 func (h *MatchHandler) GetAll(c *gin.Context) {
+	var query dto.MatchListQuery
+	bindErr := c.ShouldBindQuery(&query)
+	if err := utils.ValidationError(bindErr, validator.ValidateMatchListQuery(query)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
 	page, limit := utils.GetPaginationParams(c)
 
-	matches, meta, err := h.matchService.GetAll(page, limit)
+	matches, meta, err := h.matchService.GetAll(query, page, limit)
 	if err != nil {
-		utils.SendInternalError(c, "Gagal mengambil data pertandingan", err.Error())
+		utils.SendError(c, "Gagal mengambil data pertandingan", err)
 		return
 	}
 
 	utils.SendPaginated(c, "Data pertandingan berhasil diambil", matches, meta)
 }
 
-// Update handles updating a match
-// @Summary Update a match
+// GetByTeamID handles getting a team's fixtures and results
+// @Summary Get matches by team ID
+// @Tags matches
+// @Produce json
+// @Param teamId path int true "Team ID"
+// @Param upcoming query bool false "Only upcoming fixtures"
+// @Param past query bool false "Only past matches"
+// @Param home query bool false "Only home matches"
+// @Param away query bool false "Only away matches"
+// @Param status query string false "Match status"
+// @Param date_from query string false "Start date (YYYY-MM-DD)"
+// @Param date_to query string false "End date (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.PaginatedResponse
+// @Router /teams/{teamId}/matches [get]
+func (h *MatchHandler) GetByTeamID(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "Team ID tidak valid", err.Error())
+		return
+	}
+
+	var query dto.TeamMatchesQuery
+	bindErr := c.ShouldBindQuery(&query)
+	if err := utils.ValidationError(bindErr, validator.ValidateTeamMatchesQuery(query)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	page, limit := utils.GetPaginationParams(c)
+
+	matches, meta, err := h.matchService.GetByTeamID(teamID, query, page, limit)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil data pertandingan tim", err)
+		return
+	}
+
+	utils.SendPaginated(c, "Data pertandingan tim berhasil diambil", matches, meta)
+}
+
+// Update handles replacing a match
+// @Summary Replace a match
 // @Tags matches
 // @Accept json
 // @Produce json
 // @Param id path int true "Match ID"
 // @Param match body dto.UpdateMatchRequest true "Match data"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
 // @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
 // @Router /matches/{id} [put]
 func (h *MatchHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -107,23 +173,72 @@ func (h *MatchHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req dto.UpdateMatchRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendBadRequest(c, "Data tidak valid", utils.FormatValidationError(err))
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateUpdateMatch(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	match, err := h.matchService.Update(currentActor(c), id, version, req)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui pertandingan", err)
+		return
+	}
+
+	utils.SetETag(c, match.Version)
+	utils.SendSuccess(c, "Pertandingan berhasil diperbarui", match)
+}
+
+// Patch handles partially updating a match with a JSON merge patch (RFC 7396).
+// Members set to null are cleared, omitted members keep their current value.
+// @Summary Patch a match
+// @Tags matches
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Match ID"
+// @Param match body object true "Merge patch document"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
+// @Router /matches/{id} [patch]
+func (h *MatchHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	if err := validator.ValidateUpdateMatch(req); err != nil {
-		utils.SendBadRequest(c, "Validasi gagal", err.Error())
+	if !utils.IsMergePatchRequest(c) {
+		utils.SendUnsupportedMediaType(c, "Content-Type tidak didukung", "gunakan "+utils.MergePatchContentType)
 		return
 	}
 
-	match, err := h.matchService.Update(id, req)
+	patch, err := c.GetRawData()
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal memperbarui pertandingan", err.Error())
+		utils.SendBadRequest(c, "Data tidak valid", err.Error())
 		return
 	}
 
+	match, err := h.matchService.Patch(currentActor(c), id, version, patch)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui pertandingan", err)
+		return
+	}
+
+	utils.SetETag(c, match.Version)
 	utils.SendSuccess(c, "Pertandingan berhasil diperbarui", match)
 }
 
@@ -134,7 +249,11 @@ func (h *MatchHandler) Update(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Match ID"
 // @Param result body dto.UpdateMatchResultRequest true "Match result data"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
 // @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
 // @Router /matches/{id}/result [put]
 func (h *MatchHandler) UpdateResult(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -143,23 +262,25 @@ func (h *MatchHandler) UpdateResult(c *gin.Context) {
 		return
 	}
 
-	var req dto.UpdateMatchResultRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendBadRequest(c, "Data tidak valid", utils.FormatValidationError(err))
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	if err := validator.ValidateUpdateMatchResult(req); err != nil {
-		utils.SendBadRequest(c, "Validasi gagal", err.Error())
+	var req dto.UpdateMatchResultRequest
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateUpdateMatchResult(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
 		return
 	}
 
-	match, err := h.matchService.UpdateResult(id, req)
+	match, err := h.matchService.UpdateResult(currentActor(c), id, version, req)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal memperbarui hasil pertandingan", err.Error())
+		utils.SendError(c, "Gagal memperbarui hasil pertandingan", err)
 		return
 	}
 
+	utils.SetETag(c, match.Version)
 	utils.SendSuccess(c, "Hasil pertandingan berhasil diperbarui", match)
 }
 
@@ -168,7 +289,11 @@ func (h *MatchHandler) UpdateResult(c *gin.Context) {
 // @Tags matches
 // @Produce json
 // @Param id path int true "Match ID"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
 // @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
 // @Router /matches/{id} [delete]
 func (h *MatchHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -177,9 +302,14 @@ func (h *MatchHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.matchService.Delete(id)
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	err = h.matchService.Delete(currentActor(c), id, version)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal menghapus pertandingan", err.Error())
+		utils.SendError(c, "Gagal menghapus pertandingan", err)
 		return
 	}
 
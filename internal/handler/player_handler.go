@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/service"
 	"football-management-api/internal/utils"
@@ -25,26 +26,24 @@ func NewPlayerHandler(playerService service.PlayerService) *PlayerHandler {
 // @Produce json
 // @Param player body dto.CreatePlayerRequest true "Player data"
 // @Success 201 {object} dto.Response
+// @Failure 403 {object} dto.Response
 // @Router /players [post]
 func (h *PlayerHandler) Create(c *gin.Context) {
 	var req dto.CreatePlayerRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendBadRequest(c, "Data tidak valid", utils.FormatValidationError(err))
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateCreatePlayer(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
 		return
 	}
 
-	if err := validator.ValidateCreatePlayer(req); err != nil {
-		utils.SendBadRequest(c, "Validasi gagal", err.Error())
-		return
-	}
-
-	player, err := h.playerService.Create(req)
+	player, err := h.playerService.Create(currentActor(c), req)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal membuat pemain", err.Error())
+		utils.SendError(c, "Gagal membuat pemain", err)
 		return
 	}
 
+	utils.SetETag(c, player.Version)
 	utils.SendCreated(c, "Pemain berhasil dibuat", player)
 }
 
@@ -53,6 +52,7 @@ func (h *PlayerHandler) Create(c *gin.Context) {
 // @Tags players
 // @Produce json
 // @Param id path int true "Player ID"
+// @Param include query string false "Relations to embed: team,goals"
 // @Success 200 {object} dto.Response
 // @Router /players/{id} [get]
 func (h *PlayerHandler) GetByID(c *gin.Context) {
@@ -62,29 +62,51 @@ func (h *PlayerHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	player, err := h.playerService.GetByID(id)
+	include := c.Query("include")
+	if err := validator.ValidateIncludes(include, config.PlayerIncludes()); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	player, err := h.playerService.GetByID(id, utils.ParseIncludes(include))
 	if err != nil {
-		utils.SendNotFound(c, "Pemain tidak ditemukan", err.Error())
+		utils.SendError(c, "Gagal mengambil data pemain", err)
 		return
 	}
 
+	utils.SetETag(c, player.Version)
 	utils.SendSuccess(c, "Pemain ditemukan", player)
 }
 
-// GetAll handles getting all players with pagination
+// GetAll handles getting all players with filters, search, sorting and pagination
 // @Summary Get all players
 // @Tags players
 // @Produce json
+// @Param q query string false "Search by player name"
+// @Param sort query string false "Sort fields, e.g. -height,name"
+// @Param team_id query int false "Filter by team ID"
+// @Param position query string false "Filter by position"
+// @Param height_min query number false "Minimum height (cm)"
+// @Param height_max query number false "Maximum height (cm)"
+// @Param cursor query string false "Cursor for keyset pagination, empty for the first page"
+// @Param include query string false "Relations to embed: team,goals"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} dto.PaginatedResponse
 // @Router /players [get]
 func (h *PlayerHandler) GetAll(c *gin.Context) {
+	var query dto.PlayerListQuery
+	bindErr := c.ShouldBindQuery(&query)
+	if err := utils.ValidationError(bindErr, validator.ValidatePlayerListQuery(query)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
 	page, limit := utils.GetPaginationParams(c)
 
-	players, meta, err := h.playerService.GetAll(page, limit)
+	players, meta, err := h.playerService.GetAll(query, page, limit)
 	if err != nil {
-		utils.SendInternalError(c, "Gagal mengambil data pemain", err.Error())
+		utils.SendError(c, "Gagal mengambil data pemain", err)
 		return
 	}
 
@@ -107,21 +129,61 @@ func (h *PlayerHandler) GetByTeamID(c *gin.Context) {
 
 	players, err := h.playerService.GetByTeamID(teamID)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal mengambil data pemain", err.Error())
+		utils.SendError(c, "Gagal mengambil data pemain", err)
 		return
 	}
 
 	utils.SendSuccess(c, "Data pemain berhasil diambil", players)
 }
 
-// Update handles updating a player
-// @Summary Update a player
+// GetMatches handles getting a player's match history
+// @Summary Get player match history
+// @Tags players
+// @Produce json
+// @Param id path int true "Player ID"
+// @Param date_from query string false "Start date (YYYY-MM-DD)"
+// @Param date_to query string false "End date (YYYY-MM-DD)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.PaginatedResponse
+// @Router /players/{id}/matches [get]
+func (h *PlayerHandler) GetMatches(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	var query dto.PlayerMatchesQuery
+	bindErr := c.ShouldBindQuery(&query)
+	if err := utils.ValidationError(bindErr, validator.ValidateDateRange(query.DateFrom, query.DateTo)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	page, limit := utils.GetPaginationParams(c)
+
+	matches, meta, err := h.playerService.GetMatches(id, query, page, limit)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil riwayat pertandingan pemain", err)
+		return
+	}
+
+	utils.SendPaginated(c, "Riwayat pertandingan pemain berhasil diambil", matches, meta)
+}
+
+// Update handles replacing a player
+// @Summary Replace a player
 // @Tags players
 // @Accept json
 // @Produce json
 // @Param id path int true "Player ID"
 // @Param player body dto.UpdatePlayerRequest true "Player data"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
 // @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
 // @Router /players/{id} [put]
 func (h *PlayerHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -130,23 +192,72 @@ func (h *PlayerHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req dto.UpdatePlayerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendBadRequest(c, "Data tidak valid", utils.FormatValidationError(err))
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateUpdatePlayer(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
 		return
 	}
 
-	if err := validator.ValidateUpdatePlayer(req); err != nil {
-		utils.SendBadRequest(c, "Validasi gagal", err.Error())
+	player, err := h.playerService.Update(currentActor(c), id, version, req)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui pemain", err)
 		return
 	}
 
-	player, err := h.playerService.Update(id, req)
+	utils.SetETag(c, player.Version)
+	utils.SendSuccess(c, "Pemain berhasil diperbarui", player)
+}
+
+// Patch handles partially updating a player with a JSON merge patch (RFC 7396).
+// Members set to null are cleared, omitted members keep their current value.
+// @Summary Patch a player
+// @Tags players
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Player ID"
+// @Param player body object true "Merge patch document"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
+// @Router /players/{id} [patch]
+func (h *PlayerHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal memperbarui pemain", err.Error())
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
 		return
 	}
 
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if !utils.IsMergePatchRequest(c) {
+		utils.SendUnsupportedMediaType(c, "Content-Type tidak didukung", "gunakan "+utils.MergePatchContentType)
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		utils.SendBadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	player, err := h.playerService.Patch(currentActor(c), id, version, patch)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui pemain", err)
+		return
+	}
+
+	utils.SetETag(c, player.Version)
 	utils.SendSuccess(c, "Pemain berhasil diperbarui", player)
 }
 
@@ -155,7 +266,11 @@ func (h *PlayerHandler) Update(c *gin.Context) {
 // @Tags players
 // @Produce json
 // @Param id path int true "Player ID"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
 // @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
 // @Router /players/{id} [delete]
 func (h *PlayerHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -164,9 +279,14 @@ func (h *PlayerHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.playerService.Delete(id)
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	err = h.playerService.Delete(currentActor(c), id, version)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal menghapus pemain", err.Error())
+		utils.SendError(c, "Gagal menghapus pemain", err)
 		return
 	}
 
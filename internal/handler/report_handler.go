@@ -32,7 +32,7 @@ func (h *ReportHandler) GetMatchReport(c *gin.Context) {
 
 	report, err := h.reportService.GetMatchReport(matchID)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal mengambil laporan pertandingan", err.Error())
+		utils.SendError(c, "Gagal mengambil laporan pertandingan", err)
 		return
 	}
 
@@ -55,13 +55,44 @@ func (h *ReportHandler) GetTeamStatistics(c *gin.Context) {
 
 	stats, err := h.reportService.GetTeamStatistics(teamID)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal mengambil statistik tim", err.Error())
+		utils.SendError(c, "Gagal mengambil statistik tim", err)
 		return
 	}
 
 	utils.SendSuccess(c, "Statistik tim berhasil diambil", stats)
 }
 
+// GetTeamForm handles getting a team's form guide and streaks
+// @Summary Get team form guide
+// @Tags reports
+// @Produce json
+// @Param teamId path int true "Team ID"
+// @Param last query int false "Number of recent results" default(5)
+// @Success 200 {object} dto.Response
+// @Router /reports/teams/{teamId}/form [get]
+func (h *ReportHandler) GetTeamForm(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "Team ID tidak valid", err.Error())
+		return
+	}
+
+	last := 5
+	if lastStr := c.Query("last"); lastStr != "" {
+		if l, err := strconv.Atoi(lastStr); err == nil && l > 0 {
+			last = l
+		}
+	}
+
+	form, err := h.reportService.GetTeamForm(teamID, last)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil performa tim", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Performa tim berhasil diambil", form)
+}
+
 // GetPlayerStatistics handles getting player statistics
 // @Summary Get player statistics
 // @Tags reports
@@ -78,7 +109,7 @@ func (h *ReportHandler) GetPlayerStatistics(c *gin.Context) {
 
 	stats, err := h.reportService.GetPlayerStatistics(playerID)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal mengambil statistik pemain", err.Error())
+		utils.SendError(c, "Gagal mengambil statistik pemain", err)
 		return
 	}
 
@@ -102,9 +133,64 @@ func (h *ReportHandler) GetTopScorers(c *gin.Context) {
 
 	scorers, err := h.reportService.GetTopScorers(limit)
 	if err != nil {
-		utils.SendInternalError(c, "Gagal mengambil data top scorer", err.Error())
+		utils.SendError(c, "Gagal mengambil data top scorer", err)
 		return
 	}
 
 	utils.SendSuccess(c, "Data top scorer berhasil diambil", scorers)
 }
+
+// GetHeadToHead handles getting the head-to-head report between two teams
+// @Summary Get head-to-head report
+// @Tags reports
+// @Produce json
+// @Param team_a query int true "First team ID"
+// @Param team_b query int true "Second team ID"
+// @Success 200 {object} dto.Response
+// @Router /reports/head-to-head [get]
+func (h *ReportHandler) GetHeadToHead(c *gin.Context) {
+	teamAID, err := strconv.Atoi(c.Query("team_a"))
+	if err != nil {
+		utils.SendBadRequest(c, "team_a tidak valid", "team_a wajib diisi dengan ID tim")
+		return
+	}
+
+	teamBID, err := strconv.Atoi(c.Query("team_b"))
+	if err != nil {
+		utils.SendBadRequest(c, "team_b tidak valid", "team_b wajib diisi dengan ID tim")
+		return
+	}
+
+	report, err := h.reportService.GetHeadToHead(teamAID, teamBID)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil laporan head-to-head", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Laporan head-to-head berhasil diambil", report)
+}
+
+// GetCleanSheets handles getting the clean sheet leaderboard
+// @Summary Get clean sheet leaderboard
+// @Tags reports
+// @Produce json
+// @Param position query string false "Penjaga Gawang or Bertahan"
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {object} dto.Response
+// @Router /reports/clean-sheets [get]
+func (h *ReportHandler) GetCleanSheets(c *gin.Context) {
+	limit := 10
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	leaders, err := h.reportService.GetCleanSheets(c.Query("position"), limit)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil data clean sheet", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Data clean sheet berhasil diambil", leaders)
+}
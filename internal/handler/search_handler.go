@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"football-management-api/internal/dto"
+	"football-management-api/internal/service"
+	"football-management-api/internal/utils"
+	"football-management-api/internal/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	searchService service.SearchService
+}
+
+func NewSearchHandler(searchService service.SearchService) *SearchHandler {
+	return &SearchHandler{searchService: searchService}
+}
+
+// Search handles fuzzy search across teams, players and matches
+// @Summary Search teams, players and matches
+// @Tags search
+// @Produce json
+// @Param q query string true "Search term, typos and missing accents are tolerated"
+// @Param type query string false "Comma separated result types: team, player, match"
+// @Param limit query int false "Maximum number of results" default(20)
+// @Success 200 {object} dto.Response
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var query dto.SearchQuery
+	bindErr := c.ShouldBindQuery(&query)
+	if err := utils.ValidationError(bindErr, validator.ValidateSearchQuery(query)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	results, err := h.searchService.Search(query)
+	if err != nil {
+		utils.SendError(c, "Gagal melakukan pencarian", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Pencarian berhasil", results)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/service"
 	"football-management-api/internal/utils"
@@ -25,26 +26,24 @@ func NewTeamHandler(teamService service.TeamService) *TeamHandler {
 // @Produce json
 // @Param team body dto.CreateTeamRequest true "Team data"
 // @Success 201 {object} dto.Response
+// @Failure 403 {object} dto.Response
 // @Router /teams [post]
 func (h *TeamHandler) Create(c *gin.Context) {
 	var req dto.CreateTeamRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendBadRequest(c, "Data tidak valid", utils.FormatValidationError(err))
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateCreateTeam(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
 		return
 	}
 
-	if err := validator.ValidateCreateTeam(req); err != nil {
-		utils.SendBadRequest(c, "Validasi gagal", err.Error())
-		return
-	}
-
-	team, err := h.teamService.Create(req)
+	team, err := h.teamService.Create(currentActor(c), req)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal membuat tim", err.Error())
+		utils.SendError(c, "Gagal membuat tim", err)
 		return
 	}
 
+	utils.SetETag(c, team.Version)
 	utils.SendCreated(c, "Tim berhasil dibuat", team)
 }
 
@@ -53,6 +52,7 @@ func (h *TeamHandler) Create(c *gin.Context) {
 // @Tags teams
 // @Produce json
 // @Param id path int true "Team ID"
+// @Param include query string false "Relations to embed: players,matches,matches.goals"
 // @Success 200 {object} dto.Response
 // @Router /teams/{id} [get]
 func (h *TeamHandler) GetByID(c *gin.Context) {
@@ -62,43 +62,69 @@ func (h *TeamHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	team, err := h.teamService.GetByID(id)
+	include := c.Query("include")
+	if err := validator.ValidateIncludes(include, config.TeamIncludes()); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	team, err := h.teamService.GetByID(id, utils.ParseIncludes(include))
 	if err != nil {
-		utils.SendNotFound(c, "Tim tidak ditemukan", err.Error())
+		utils.SendError(c, "Gagal mengambil data tim", err)
 		return
 	}
 
+	utils.SetETag(c, team.Version)
 	utils.SendSuccess(c, "Tim ditemukan", team)
 }
 
-// GetAll handles getting all teams with pagination
+// GetAll handles getting all teams with filters, search, sorting and pagination
 // @Summary Get all teams
 // @Tags teams
 // @Produce json
+// @Param q query string false "Search by team name"
+// @Param sort query string false "Sort fields, e.g. -founded_year,name"
+// @Param city query string false "Filter by home city"
+// @Param founded_from query int false "Minimum founded year"
+// @Param founded_to query int false "Maximum founded year"
+// @Param archived query bool false "Only archived (true) or only unarchived (false) teams"
+// @Param cursor query string false "Cursor for keyset pagination, empty for the first page"
+// @Param include query string false "Relations to embed: players,matches,matches.goals"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Success 200 {object} dto.PaginatedResponse
 // @Router /teams [get]
 func (h *TeamHandler) GetAll(c *gin.Context) {
+	var query dto.TeamListQuery
+	bindErr := c.ShouldBindQuery(&query)
+	if err := utils.ValidationError(bindErr, validator.ValidateTeamListQuery(query)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
 	page, limit := utils.GetPaginationParams(c)
 
-	teams, meta, err := h.teamService.GetAll(page, limit)
+	teams, meta, err := h.teamService.GetAll(query, page, limit)
 	if err != nil {
-		utils.SendInternalError(c, "Gagal mengambil data tim", err.Error())
+		utils.SendError(c, "Gagal mengambil data tim", err)
 		return
 	}
 
 	utils.SendPaginated(c, "Data tim berhasil diambil", teams, meta)
 }
 
-// Update handles updating a team
-// @Summary Update a team
+// Update handles replacing a team
+// @Summary Replace a team
 // @Tags teams
 // @Accept json
 // @Produce json
 // @Param id path int true "Team ID"
 // @Param team body dto.UpdateTeamRequest true "Team data"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
 // @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
 // @Router /teams/{id} [put]
 func (h *TeamHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -107,32 +133,91 @@ func (h *TeamHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req dto.UpdateTeamRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.SendBadRequest(c, "Data tidak valid", utils.FormatValidationError(err))
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateUpdateTeam(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
 		return
 	}
 
-	if err := validator.ValidateUpdateTeam(req); err != nil {
-		utils.SendBadRequest(c, "Validasi gagal", err.Error())
+	team, err := h.teamService.Update(currentActor(c), id, version, req)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui tim", err)
 		return
 	}
 
-	team, err := h.teamService.Update(id, req)
+	utils.SetETag(c, team.Version)
+	utils.SendSuccess(c, "Tim berhasil diperbarui", team)
+}
+
+// Patch handles partially updating a team with a JSON merge patch (RFC 7396).
+// Members set to null are cleared, omitted members keep their current value.
+// @Summary Patch a team
+// @Tags teams
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "Team ID"
+// @Param team body object true "Merge patch document"
+// @Param If-Match header string true "ETag of the resource, or * for any version"
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
+// @Router /teams/{id} [patch]
+func (h *TeamHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal memperbarui tim", err.Error())
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
 		return
 	}
 
+	if !utils.IsMergePatchRequest(c) {
+		utils.SendUnsupportedMediaType(c, "Content-Type tidak didukung", "gunakan "+utils.MergePatchContentType)
+		return
+	}
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		utils.SendBadRequest(c, "Data tidak valid", err.Error())
+		return
+	}
+
+	team, err := h.teamService.Patch(currentActor(c), id, version, patch)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui tim", err)
+		return
+	}
+
+	utils.SetETag(c, team.Version)
 	utils.SendSuccess(c, "Tim berhasil diperbarui", team)
 }
 
-// Delete handles deleting a team
+// Delete handles deleting a team with a delete policy. restrict (the default) deletes only a
+// team without active players and matches, cascade deletes its players with it, and archive
+// keeps the team for its match history. With dry_run the affected players and matches are
+// previewed and nothing is changed, so no If-Match is needed.
 // @Summary Delete a team
 // @Tags teams
 // @Produce json
 // @Param id path int true "Team ID"
-// @Success 200 {object} dto.Response
+// @Param policy query string false "Delete policy: restrict, cascade or archive"
+// @Param dry_run query bool false "Only preview the affected records"
+// @Param If-Match header string false "ETag of the resource, or * for any version; required unless dry_run"
+// @Success 200 {object} dto.Response{data=dto.TeamDeletePreview}
+// @Failure 403 {object} dto.Response
+// @Failure 409 {object} dto.Response
+// @Failure 412 {object} dto.Response
+// @Failure 428 {object} dto.Response
 // @Router /teams/{id} [delete]
 func (h *TeamHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -141,11 +226,40 @@ func (h *TeamHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	err = h.teamService.Delete(id)
+	var query dto.TeamDeleteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.SendError(c, "Parameter tidak valid", utils.ValidationError(err, nil))
+		return
+	}
+	if query.Policy == "" {
+		query.Policy = config.TeamDeleteRestrict
+	}
+
+	if query.DryRun {
+		preview, err := h.teamService.PreviewDelete(currentActor(c), id, query.Policy)
+		if err != nil {
+			utils.SendError(c, "Gagal membuat pratinjau penghapusan tim", err)
+			return
+		}
+
+		utils.SendSuccess(c, "Pratinjau penghapusan tim", preview)
+		return
+	}
+
+	version, ok := utils.GetIfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.teamService.Delete(currentActor(c), id, version, query.Policy)
 	if err != nil {
-		utils.SendBadRequest(c, "Gagal menghapus tim", err.Error())
+		utils.SendError(c, "Gagal menghapus tim", err)
 		return
 	}
 
-	utils.SendSuccess(c, "Tim berhasil dihapus", nil)
+	message := "Tim berhasil dihapus"
+	if query.Policy == config.TeamDeleteArchive {
+		message = "Tim berhasil diarsipkan"
+	}
+	utils.SendSuccess(c, message, result)
 }
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"football-management-api/internal/config"
+	"football-management-api/internal/service"
+	"football-management-api/internal/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	trashService service.TrashService
+}
+
+func NewTrashHandler(trashService service.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+// GetAll handles getting the deleted records of an entity
+// @Summary Get deleted records (admin only)
+// @Tags trash
+// @Produce json
+// @Param entity path string true "Entity (teams, players, matches, goals)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /trash/{entity} [get]
+func (h *TrashHandler) GetAll(c *gin.Context) {
+	entity, ok := trashEntity(c)
+	if !ok {
+		return
+	}
+
+	page, limit := utils.GetPaginationParams(c)
+
+	items, meta, err := h.trashService.GetAll(currentActor(c), entity, page, limit)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil data trash", err)
+		return
+	}
+
+	utils.SendPaginated(c, "Data trash berhasil diambil", items, meta)
+}
+
+// Restore handles restoring a deleted record
+// @Summary Restore a deleted record (admin only)
+// @Tags trash
+// @Produce json
+// @Param entity path string true "Entity (teams, players, matches, goals)"
+// @Param id path int true "Record ID"
+// @Success 200 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /trash/{entity}/{id}/restore [post]
+func (h *TrashHandler) Restore(c *gin.Context) {
+	entity, ok := trashEntity(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	if err := h.trashService.Restore(currentActor(c), entity, id); err != nil {
+		utils.SendError(c, "Gagal memulihkan data", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Data berhasil dipulihkan", nil)
+}
+
+// Purge handles permanently deleting a deleted record
+// @Summary Permanently delete a deleted record (admin only)
+// @Description Deleted records referencing it are removed as well. Records still referenced by active records cannot be purged.
+// @Tags trash
+// @Produce json
+// @Param entity path string true "Entity (teams, players, matches, goals)"
+// @Param id path int true "Record ID"
+// @Success 200 {object} dto.Response
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 409 {object} dto.Response
+// @Router /trash/{entity}/{id} [delete]
+func (h *TrashHandler) Purge(c *gin.Context) {
+	entity, ok := trashEntity(c)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	if err := h.trashService.Purge(currentActor(c), entity, id); err != nil {
+		utils.SendError(c, "Gagal menghapus data secara permanen", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Data berhasil dihapus secara permanen", nil)
+}
+
+// PurgeExpired handles permanently deleting every record past the retention period
+// @Summary Purge records deleted longer ago than the retention period (admin only)
+// @Tags trash
+// @Produce json
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /trash/purge [post]
+func (h *TrashHandler) PurgeExpired(c *gin.Context) {
+	result, err := h.trashService.PurgeExpired(currentActor(c))
+	if err != nil {
+		utils.SendError(c, "Gagal membersihkan trash", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Trash berhasil dibersihkan", result)
+}
+
+// trashEntity returns the entity path parameter, answering 400 when it has no trash
+func trashEntity(c *gin.Context) (string, bool) {
+	entity := c.Param("entity")
+	if !utils.Contains(config.TrashEntities(), entity) {
+		utils.SendBadRequest(c, "Entity tidak valid", "pilihan: "+strings.Join(config.TrashEntities(), ", "))
+		return "", false
+	}
+	return entity, true
+}
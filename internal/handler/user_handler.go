@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"football-management-api/internal/dto"
+	"football-management-api/internal/service"
+	"football-management-api/internal/utils"
+	"football-management-api/internal/validator"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	userService service.UserService
+}
+
+func NewUserHandler(userService service.UserService) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+// GetAll handles getting all users
+// @Summary Get all users (admin only)
+// @Tags users
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} dto.PaginatedResponse
+// @Failure 403 {object} dto.Response
+// @Router /users [get]
+func (h *UserHandler) GetAll(c *gin.Context) {
+	page, limit := utils.GetPaginationParams(c)
+
+	users, meta, err := h.userService.GetAll(currentActor(c), page, limit)
+	if err != nil {
+		utils.SendError(c, "Gagal mengambil data pengguna", err)
+		return
+	}
+
+	utils.SendPaginated(c, "Data pengguna berhasil diambil", users, meta)
+}
+
+// UpdateRole handles assigning a role to a user
+// @Summary Assign a role to a user (admin only)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param role body dto.UpdateUserRoleRequest true "Role data"
+// @Success 200 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Router /users/{id}/role [put]
+func (h *UserHandler) UpdateRole(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.SendBadRequest(c, "ID tidak valid", err.Error())
+		return
+	}
+
+	var req dto.UpdateUserRoleRequest
+	bindErr := c.ShouldBindJSON(&req)
+	if err := utils.ValidationError(bindErr, validator.ValidateUpdateUserRole(req)); err != nil {
+		utils.SendError(c, "Validasi gagal", err)
+		return
+	}
+
+	user, err := h.userService.UpdateRole(currentActor(c), id, req)
+	if err != nil {
+		utils.SendError(c, "Gagal memperbarui role pengguna", err)
+		return
+	}
+
+	utils.SendSuccess(c, "Role pengguna berhasil diperbarui", user)
+}
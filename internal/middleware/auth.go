@@ -1,23 +1,34 @@
 package middleware
 
 import (
-	"football-management-api/internal/config"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/auth"
 	"football-management-api/internal/dto"
+	"football-management-api/pkg/logger"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-// AuthMiddleware is a middleware for JWT authentication
-// Note: This is a basic implementation. In production, you should implement proper user authentication
-func AuthMiddleware() gin.HandlerFunc {
+// RevocationChecker reports whether a validly signed token was revoked before it expired
+type RevocationChecker func(claims *auth.Claims) (bool, error)
+
+// AuthMiddleware is a middleware for JWT authentication. The user of a valid, unrevoked token
+// is stored in the context as "user_id", "username", "actor" and "claims". Requests already
+// authenticated by APIKeyAuth are let through.
+func AuthMiddleware(isRevoked RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if _, ok := apiKeyActor(c); ok {
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, dto.ErrorResponse(
+				"TOKEN_MISSING",
 				"Unauthorized",
 				"Token tidak ditemukan",
 			))
@@ -29,6 +40,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
 			c.JSON(http.StatusUnauthorized, dto.ErrorResponse(
+				"TOKEN_MALFORMED",
 				"Unauthorized",
 				"Format token tidak valid",
 			))
@@ -39,16 +51,10 @@ func AuthMiddleware() gin.HandlerFunc {
 		tokenString := parts[1]
 
 		// Parse and validate token
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Validate the algorithm
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(config.GlobalConfig.JWT.Secret), nil
-		})
-
-		if err != nil || !token.Valid {
+		claims, err := auth.ParseToken(tokenString)
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, dto.ErrorResponse(
+				"TOKEN_INVALID",
 				"Unauthorized",
 				"Token tidak valid atau sudah kadaluarsa",
 			))
@@ -56,19 +62,36 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// Extract claims
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			c.Set("user_id", claims["user_id"])
-			c.Set("username", claims["username"])
+		revoked, err := isRevoked(claims)
+		if err != nil {
+			logger.Error("Failed to check token revocation: " + err.Error())
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(
+				apperror.CodeInternal,
+				"Terjadi kesalahan pada server",
+				"Gagal memeriksa status token",
+			))
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse(
+				"TOKEN_REVOKED",
+				"Unauthorized",
+				"Token sudah dicabut, silakan login ulang",
+			))
+			c.Abort()
+			return
 		}
 
+		setUser(c, claims)
+
 		c.Next()
 	}
 }
 
 // OptionalAuth is an optional authentication middleware
 // It won't block if no token is provided, but will validate if token exists
-func OptionalAuth() gin.HandlerFunc {
+func OptionalAuth(isRevoked RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 
@@ -81,17 +104,9 @@ func OptionalAuth() gin.HandlerFunc {
 		if len(parts) == 2 && parts[0] == "Bearer" {
 			tokenString := parts[1]
 
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, jwt.ErrSignatureInvalid
-				}
-				return []byte(config.GlobalConfig.JWT.Secret), nil
-			})
-
-			if err == nil && token.Valid {
-				if claims, ok := token.Claims.(jwt.MapClaims); ok {
-					c.Set("user_id", claims["user_id"])
-					c.Set("username", claims["username"])
+			if claims, err := auth.ParseToken(tokenString); err == nil {
+				if revoked, err := isRevoked(claims); err == nil && !revoked {
+					setUser(c, claims)
 				}
 			}
 		}
@@ -99,3 +114,11 @@ func OptionalAuth() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// setUser stores the authenticated user of the token in the context
+func setUser(c *gin.Context, claims *auth.Claims) {
+	c.Set("user_id", claims.UserID)
+	c.Set("username", claims.Username)
+	c.Set("actor", claims.Actor())
+	c.Set("claims", claims)
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"football-management-api/internal/models"
+	"football-management-api/pkg/logger"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConditionalGet makes GET responses cacheable when their content only changes with the data
+// whose change marker is returned by dataVersion. It sets Last-Modified, ETag and
+// Cache-Control, and answers 304 Not Modified when the client's copy is still current
+// (If-None-Match, or If-Modified-Since when no If-None-Match is sent).
+func ConditionalGet(dataVersion func() (*models.DataVersion, error), maxAge int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		version, err := dataVersion()
+		if err != nil {
+			// Serve the response uncached rather than failing the request
+			logger.Error("Failed to get data version: " + err.Error())
+			c.Next()
+			return
+		}
+
+		// HTTP dates have a resolution of one second, so the ETag is derived from the version
+		// to tell apart changes within the same second
+		modified := version.ChangedAt.UTC().Truncate(time.Second)
+		etag := responseETag(c.Request.URL.RequestURI(), version.Version)
+
+		c.Header("ETag", etag)
+		c.Header("Last-Modified", modified.Format(http.TimeFormat))
+		c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+
+		if notModified(c.Request, etag, modified) {
+			c.AbortWithStatus(http.StatusNotModified)
+			return
+		}
+
+		c.Writer = &cacheableWriter{ResponseWriter: c.Writer}
+		c.Next()
+	}
+}
+
+// responseETag derives a weak entity tag from the request URI and the data version, so every
+// URL gets its own tag that changes whenever the underlying data does
+func responseETag(uri string, version int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d", uri, version)))
+	return fmt.Sprintf(`W/"%x"`, sum[:16])
+}
+
+// notModified reports whether the client's cached copy is still current
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(tag)
+			// If-None-Match uses weak comparison
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := r.Header.Get("If-Modified-Since"); header != "" {
+		since, err := http.ParseTime(header)
+		return err == nil && !modified.After(since)
+	}
+
+	return false
+}
+
+// cacheableWriter drops the caching headers from error responses so they are never cached
+type cacheableWriter struct {
+	gin.ResponseWriter
+}
+
+func (w *cacheableWriter) WriteHeader(code int) {
+	if code != http.StatusOK {
+		w.Header().Del("ETag")
+		w.Header().Del("Last-Modified")
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
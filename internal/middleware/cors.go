@@ -10,8 +10,8 @@ func CORS() gin.HandlerFunc {
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
 	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	config.ExposeHeaders = []string{"Content-Length"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", "X-Request-ID", "If-Match", "If-None-Match", "If-Modified-Since"}
+	config.ExposeHeaders = []string{"Content-Length", "ETag", "Last-Modified", "X-Request-ID"}
 	config.AllowCredentials = true
 
 	return cors.New(config)
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"football-management-api/internal/apperror"
 	"football-management-api/internal/dto"
 	"football-management-api/pkg/logger"
 	"net/http"
@@ -19,10 +20,11 @@ func ErrorHandler() gin.HandlerFunc {
 
 			logger.Error(err.Error())
 
-			// Return error response
+			// Return error response; the error text stays in the log
 			c.JSON(http.StatusInternalServerError, dto.ErrorResponse(
+				apperror.CodeInternal,
 				"Terjadi kesalahan pada server",
-				err.Error(),
+				"Internal server error",
 			))
 		}
 	}
@@ -34,6 +36,7 @@ func Recovery() gin.HandlerFunc {
 		logger.Error("Panic recovered: " + recovered.(error).Error())
 
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse(
+			apperror.CodeInternal,
 			"Terjadi kesalahan yang tidak terduga",
 			"Internal server error",
 		))
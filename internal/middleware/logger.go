@@ -28,12 +28,13 @@ func Logger() gin.HandlerFunc {
 
 		// Log the request
 		logMessage := fmt.Sprintf(
-			"%s | %d | %v | %s | %s",
+			"%s | %d | %v | %s | %s | %s",
 			clientIP,
 			statusCode,
 			latency,
 			method,
 			path,
+			c.GetString("request_id"),
 		)
 
 		if statusCode >= 500 {
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// APIKey is a credential for machine-to-machine integrations. Only a hash of the key is
+// stored; the prefix identifies the key in listings and logs.
+type APIKey struct {
+	ID         int           `json:"id" db:"id"`
+	Name       string        `json:"name" db:"name"`
+	Prefix     string        `json:"prefix" db:"prefix"`
+	KeyHash    string        `json:"-" db:"key_hash"`
+	Scopes     []string      `json:"scopes" db:"scopes"`
+	ExpiresAt  sql.NullTime  `json:"expires_at" db:"expires_at"`
+	LastUsedAt sql.NullTime  `json:"last_used_at" db:"last_used_at"`
+	RevokedAt  sql.NullTime  `json:"revoked_at" db:"revoked_at"`
+	CreatedBy  sql.NullInt64 `json:"created_by" db:"created_by"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// TableName returns the table name for APIKey model
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsActive reports whether the key is neither revoked nor expired
+func (k *APIKey) IsActive() bool {
+	return !k.RevokedAt.Valid && (!k.ExpiresAt.Valid || time.Now().Before(k.ExpiresAt.Time))
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AuditEntity is the kind of record an audit entry is about
+type AuditEntity string
+
+const (
+	AuditEntityTeam   AuditEntity = "team"
+	AuditEntityPlayer AuditEntity = "player"
+	AuditEntityMatch  AuditEntity = "match"
+	AuditEntityGoal   AuditEntity = "goal"
+)
+
+// AuditAction is the kind of change an audit entry records
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+	AuditActionPurge   AuditAction = "purge"
+)
+
+// FieldChange is the value of a field before and after a change
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditLog records who changed which record, when, and how
+type AuditLog struct {
+	ID            int64                  `json:"id" db:"id"`
+	Entity        AuditEntity            `json:"entity" db:"entity"`
+	EntityID      int                    `json:"entity_id" db:"entity_id"`
+	Action        AuditAction            `json:"action" db:"action"`
+	ActorUserID   sql.NullInt64          `json:"actor_user_id" db:"actor_user_id"`
+	ActorAPIKeyID sql.NullInt64          `json:"actor_api_key_id" db:"actor_api_key_id"`
+	ActorName     string                 `json:"actor_name" db:"actor_name"`
+	RequestID     string                 `json:"request_id" db:"request_id"`
+	Changes       map[string]FieldChange `json:"changes" db:"changes"`
+	CreatedAt     time.Time              `json:"created_at" db:"created_at"`
+}
+
+// TableName returns the table name for AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// AuditFilter represents optional filters when listing audit entries
+type AuditFilter struct {
+	Entity   string
+	EntityID int
+	Actor    string
+	DateFrom string
+	DateTo   string
+}
@@ -10,9 +10,12 @@ type Goal struct {
 	ID        int          `json:"id" db:"id"`
 	MatchID   int          `json:"match_id" db:"match_id"`
 	PlayerID  int          `json:"player_id" db:"player_id"`
+	TeamID    int          `json:"team_id" db:"team_id"` // Team the scorer played for when the goal was scored
 	GoalTime  string       `json:"goal_time" db:"goal_time"`
+	Version   int          `json:"version" db:"version"`
 	DeletedAt sql.NullTime `json:"-" db:"deleted_at"`
 	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
 
 	// Relations
 	Match  *Match  `json:"match,omitempty" db:"-"`
@@ -23,3 +26,10 @@ type Goal struct {
 func (Goal) TableName() string {
 	return "goals"
 }
+
+// GoalFilter represents optional filters when listing goals
+type GoalFilter struct {
+	MatchID  int
+	PlayerID int
+	TeamID   int
+}
@@ -10,6 +10,7 @@ type MatchStatus string
 
 const (
 	StatusScheduled MatchStatus = "Scheduled"
+	StatusLive      MatchStatus = "Live"
 	StatusCompleted MatchStatus = "Completed"
 	StatusCancelled MatchStatus = "Cancelled"
 )
@@ -24,6 +25,7 @@ type Match struct {
 	HomeScore  sql.NullInt32 `json:"home_score" db:"home_score"`
 	AwayScore  sql.NullInt32 `json:"away_score" db:"away_score"`
 	Status     MatchStatus   `json:"status" db:"status"`
+	Version    int           `json:"version" db:"version"`
 	DeletedAt  sql.NullTime  `json:"-" db:"deleted_at"`
 	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt  time.Time     `json:"updated_at" db:"updated_at"`
@@ -34,15 +36,36 @@ type Match struct {
 	Goals    []Goal `json:"goals,omitempty" db:"-"`
 }
 
+// MatchFilter represents optional filters when listing matches
+type MatchFilter struct {
+	Status   MatchStatus
+	DateFrom string
+	DateTo   string
+	// Venue and Period only apply when listing matches of a single team
+	Venue  string
+	Period string
+}
+
 // TableName returns the table name for Match model
 func (Match) TableName() string {
 	return "matches"
 }
 
+// HasStarted reports whether goals can be recorded for the match
+func (m *Match) HasStarted() bool {
+	return m.Status.HasStarted()
+}
+
+// HasStarted reports whether a match with the status is live or over
+func (s MatchStatus) HasStarted() bool {
+	return s == StatusLive || s == StatusCompleted
+}
+
 // IsValidStatus checks if a status is valid
 func IsValidStatus(status string) bool {
 	validStatuses := []MatchStatus{
 		StatusScheduled,
+		StatusLive,
 		StatusCompleted,
 		StatusCancelled,
 	}
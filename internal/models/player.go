@@ -24,6 +24,7 @@ type Player struct {
 	Weight       float64        `json:"weight" db:"weight"`
 	Position     PlayerPosition `json:"position" db:"position"`
 	JerseyNumber int            `json:"jersey_number" db:"jersey_number"`
+	Version      int            `json:"version" db:"version"`
 	DeletedAt    sql.NullTime   `json:"-" db:"deleted_at"`
 	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at" db:"updated_at"`
@@ -53,3 +54,11 @@ func IsValidPosition(position string) bool {
 	}
 	return false
 }
+
+// PlayerFilter represents optional filters when listing players
+type PlayerFilter struct {
+	TeamID    int
+	Position  PlayerPosition
+	HeightMin float64
+	HeightMax float64
+}
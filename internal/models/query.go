@@ -0,0 +1,34 @@
+package models
+
+// SortField represents a single field a list is ordered by
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ListOptions represents search, sorting and pagination shared by list queries
+type ListOptions struct {
+	Search string
+	Sort   []SortField
+	Limit  int
+	Offset int
+	// Cursor switches the list to keyset pagination. A nil cursor uses Limit and Offset,
+	// an empty cursor starts at the first page.
+	Cursor *Cursor
+}
+
+// Cursor marks the edge of a keyset page: the sort values of the row at the edge, ending
+// with its ID. Prev cursors page backwards from that row.
+type Cursor struct {
+	Sort   string        `json:"s,omitempty"`
+	Values []interface{} `json:"v"`
+	Prev   bool          `json:"p,omitempty"`
+}
+
+// PageInfo describes a fetched page. Total is only counted for offset pagination and the
+// cursors are only set for keyset pagination.
+type PageInfo struct {
+	Total      int64
+	NextCursor *Cursor
+	PrevCursor *Cursor
+}
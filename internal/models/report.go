@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // MatchReport represents a detailed match report
 type MatchReport struct {
 	MatchID      int            `json:"match_id"`
@@ -48,21 +50,149 @@ type GoalDetail struct {
 
 // TeamStatistics represents team statistics
 type TeamStatistics struct {
-	TeamID        int    `json:"team_id"`
-	TeamName      string `json:"team_name"`
-	TotalMatches  int    `json:"total_matches"`
-	TotalWins     int    `json:"total_wins"`
-	TotalDraws    int    `json:"total_draws"`
-	TotalLosses   int    `json:"total_losses"`
-	GoalsScored   int    `json:"goals_scored"`
-	GoalsConceded int    `json:"goals_conceded"`
+	TeamID              int           `json:"team_id"`
+	TeamName            string        `json:"team_name"`
+	TotalMatches        int           `json:"total_matches"`
+	TotalWins           int           `json:"total_wins"`
+	TotalDraws          int           `json:"total_draws"`
+	TotalLosses         int           `json:"total_losses"`
+	GoalsScored         int           `json:"goals_scored"`
+	GoalsConceded       int           `json:"goals_conceded"`
+	TotalPoints         int           `json:"total_points"`
+	Home                TeamRecord    `json:"home"`
+	Away                TeamRecord    `json:"away"`
+	LeagueHomeAdvantage HomeAdvantage `json:"league_home_advantage"`
+}
+
+// HomeAdvantage represents league-wide home and away performance
+type HomeAdvantage struct {
+	TotalMatches       int     `json:"total_matches"`
+	HomeWins           int     `json:"home_wins"`
+	AwayWins           int     `json:"away_wins"`
+	Draws              int     `json:"draws"`
+	HomeWinPercentage  float64 `json:"home_win_percentage"`
+	AwayWinPercentage  float64 `json:"away_win_percentage"`
+	DrawPercentage     float64 `json:"draw_percentage"`
+	HomeGoals          int     `json:"home_goals"`
+	AwayGoals          int     `json:"away_goals"`
+	AvgHomeGoals       float64 `json:"avg_home_goals"`
+	AvgAwayGoals       float64 `json:"avg_away_goals"`
+	HomePointsPerMatch float64 `json:"home_points_per_match"`
+	AwayPointsPerMatch float64 `json:"away_points_per_match"`
 }
 
 // PlayerStatistics represents player goal statistics
 type PlayerStatistics struct {
+	PlayerID   int                  `json:"player_id"`
+	PlayerName string               `json:"player_name"`
+	TeamName   string               `json:"team_name"`
+	Position   string               `json:"position"`
+	TotalGoals int                  `json:"total_goals"`
+	Defensive  *DefensiveStatistics `json:"defensive_stats,omitempty"`
+}
+
+// DefensiveStatistics represents clean sheet statistics for goalkeepers and defenders.
+// Lineups are not recorded, so every completed match of the player's team counts as played.
+type DefensiveStatistics struct {
+	MatchesPlayed         int     `json:"matches_played"`
+	CleanSheets           int     `json:"clean_sheets"`
+	GoalsConceded         int     `json:"goals_conceded"`
+	CleanSheetPercentage  float64 `json:"clean_sheet_percentage"`
+	GoalsConcededPerMatch float64 `json:"goals_conceded_per_match"`
+}
+
+// CleanSheetStatistics represents a player in the clean sheet leaderboard
+type CleanSheetStatistics struct {
 	PlayerID   int    `json:"player_id"`
 	PlayerName string `json:"player_name"`
 	TeamName   string `json:"team_name"`
 	Position   string `json:"position"`
-	TotalGoals int    `json:"total_goals"`
+	DefensiveStatistics
+}
+
+// TeamRecord represents a win/draw/loss record over a set of matches
+type TeamRecord struct {
+	Played        int `json:"played"`
+	Wins          int `json:"wins"`
+	Draws         int `json:"draws"`
+	Losses        int `json:"losses"`
+	GoalsScored   int `json:"goals_scored"`
+	GoalsConceded int `json:"goals_conceded"`
+	Points        int `json:"points"`
+}
+
+// HeadToHeadRecord represents one side's record in a head-to-head fixture
+type HeadToHeadRecord struct {
+	TeamRecord
+	Home TeamRecord `json:"home"`
+	Away TeamRecord `json:"away"`
+}
+
+// HeadToHeadMatch represents a single meeting between two teams
+type HeadToHeadMatch struct {
+	MatchID      int       `json:"match_id"`
+	MatchDate    string    `json:"match_date"`
+	MatchTime    string    `json:"match_time"`
+	HomeTeamID   int       `json:"home_team_id"`
+	HomeTeamName string    `json:"home_team_name"`
+	AwayTeamID   int       `json:"away_team_id"`
+	AwayTeamName string    `json:"away_team_name"`
+	FinalScore   ScoreInfo `json:"final_score"`
+	MatchResult  string    `json:"match_result"`
+}
+
+// HeadToHeadReport represents all past meetings between two teams
+type HeadToHeadReport struct {
+	TeamA           TeamInfo          `json:"team_a"`
+	TeamB           TeamInfo          `json:"team_b"`
+	TotalMatches    int               `json:"total_matches"`
+	TeamARecord     HeadToHeadRecord  `json:"team_a_record"`
+	TeamBRecord     HeadToHeadRecord  `json:"team_b_record"`
+	BiggestWinTeamA *HeadToHeadMatch  `json:"biggest_win_team_a"`
+	BiggestWinTeamB *HeadToHeadMatch  `json:"biggest_win_team_b"`
+	TopScorers      []TopScorerInfo   `json:"top_scorers"`
+	Meetings        []HeadToHeadMatch `json:"meetings"`
+}
+
+// FormResult represents a single result in a team's form guide
+type FormResult struct {
+	MatchID       int    `json:"match_id"`
+	MatchDate     string `json:"match_date"`
+	MatchTime     string `json:"match_time"`
+	Venue         string `json:"venue"`
+	OpponentID    int    `json:"opponent_id"`
+	OpponentName  string `json:"opponent_name"`
+	GoalsScored   int    `json:"goals_scored"`
+	GoalsConceded int    `json:"goals_conceded"`
+	Result        string `json:"result"`
+}
+
+// Streak represents the current and longest run of a kind of result
+type Streak struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+// FormStreaks represents a team's result streaks
+type FormStreaks struct {
+	Winning  Streak `json:"winning"`
+	Unbeaten Streak `json:"unbeaten"`
+	Losing   Streak `json:"losing"`
+	Scoring  Streak `json:"scoring"`
+}
+
+// TeamForm represents a team's recent results and streaks
+type TeamForm struct {
+	TeamID   int          `json:"team_id"`
+	TeamName string       `json:"team_name"`
+	Form     string       `json:"form"`
+	Results  []FormResult `json:"results"`
+	Streaks  FormStreaks  `json:"streaks"`
+}
+
+// DataVersion marks the latest change to the data reports are built from. Version grows with
+// every change; ChangedAt is the time of the latest change and never moves backwards.
+type DataVersion struct {
+	Version   int64
+	ChangedAt time.Time
 }
@@ -0,0 +1,10 @@
+package models
+
+// SearchResult represents a single ranked result of a global search
+type SearchResult struct {
+	Type     string  `json:"type"`
+	ID       int     `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle"`
+	Score    float64 `json:"score"`
+}
@@ -13,6 +13,8 @@ type Team struct {
 	FoundedYear int            `json:"founded_year" db:"founded_year"`
 	HomeAddress string         `json:"home_address" db:"home_address"`
 	HomeCity    string         `json:"home_city" db:"home_city"`
+	Version     int            `json:"version" db:"version"`
+	ArchivedAt  sql.NullTime   `json:"archived_at" db:"archived_at"`
 	DeletedAt   sql.NullTime   `json:"-" db:"deleted_at"`
 	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
@@ -22,3 +24,16 @@ type Team struct {
 func (Team) TableName() string {
 	return "teams"
 }
+
+// IsArchived reports whether the team was archived instead of deleted
+func (t *Team) IsArchived() bool {
+	return t.ArchivedAt.Valid
+}
+
+// TeamFilter represents optional filters when listing teams
+type TeamFilter struct {
+	City        string
+	FoundedFrom int
+	FoundedTo   int
+	Archived    *bool
+}
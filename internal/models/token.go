@@ -0,0 +1,24 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// RefreshToken is a single-use token that is exchanged for a new access token. Every
+// rotation creates a new token in the same family, so one family is one login session.
+type RefreshToken struct {
+	ID        int          `json:"id" db:"id"`
+	UserID    int          `json:"user_id" db:"user_id"`
+	FamilyID  string       `json:"family_id" db:"family_id"`
+	TokenHash string       `json:"-" db:"token_hash"`
+	ExpiresAt time.Time    `json:"expires_at" db:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at" db:"used_at"`
+	RevokedAt sql.NullTime `json:"revoked_at" db:"revoked_at"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+// TableName returns the table name for RefreshToken model
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// TrashItem is a soft-deleted record. The reference fields are only set for the entities
+// they belong to and are used to check that the record can be restored.
+type TrashItem struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	DeletedAt time.Time `json:"deleted_at" db:"deleted_at"`
+
+	TeamID       int `json:"-" db:"team_id"`       // player
+	JerseyNumber int `json:"-" db:"jersey_number"` // player
+	HomeTeamID   int `json:"-" db:"home_team_id"`  // match
+	AwayTeamID   int `json:"-" db:"away_team_id"`  // match
+	MatchID      int `json:"-" db:"match_id"`      // goal
+	PlayerID     int `json:"-" db:"player_id"`     // goal
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// UserRole represents the role that decides what a user may change
+type UserRole string
+
+const (
+	RoleAdmin         UserRole = "admin"
+	RoleTeamManager   UserRole = "team_manager"
+	RoleMatchOfficial UserRole = "match_official"
+	RoleViewer        UserRole = "viewer"
+)
+
+// User represents an account that can sign in to the API
+type User struct {
+	ID           int           `json:"id" db:"id"`
+	Username     string        `json:"username" db:"username"`
+	Email        string        `json:"email" db:"email"`
+	PasswordHash string        `json:"-" db:"password_hash"`
+	Role         UserRole      `json:"role" db:"role"`
+	TeamID       sql.NullInt64 `json:"team_id" db:"team_id"` // only set for team managers
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// TableName returns the table name for User model
+func (User) TableName() string {
+	return "users"
+}
@@ -0,0 +1,147 @@
+// Package policy decides which writes an authenticated user may perform.
+//
+// Roles:
+//   - admin: everything
+//   - team_manager: the players of their own team and the results of their team's matches
+//   - match_official: goals of matches that are live
+//   - viewer: read only
+//
+// API keys have no role; they may only perform the actions granted by their scopes.
+package policy
+
+import (
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
+	"football-management-api/internal/models"
+)
+
+// ErrForbidden is returned when the actor's role does not allow an action
+var ErrForbidden = apperror.Forbidden(apperror.CodeForbidden, "anda tidak memiliki akses untuk melakukan tindakan ini")
+
+// Actor is the authenticated user performing an action
+type Actor struct {
+	UserID   int
+	Username string
+	Role     models.UserRole
+	TeamID   int // team managed by a team manager, 0 for other roles
+
+	APIKeyID int      // set when authenticated with an API key instead of a user token
+	Scopes   []string // scopes granted to the API key
+
+	RequestID string // request the action is part of, recorded in the audit log
+}
+
+// IsAPIKey reports whether the actor is an API key
+func (a Actor) IsAPIKey() bool {
+	return a.APIKeyID != 0
+}
+
+// HasScope reports whether the actor is an API key granted the scope
+func (a Actor) HasScope(scope string) bool {
+	if !a.IsAPIKey() {
+		return false
+	}
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// System is the actor of scheduled jobs, such as purging the trash
+var System = Actor{Username: "system", Role: models.RoleAdmin}
+
+// IsAdmin reports whether the actor is a league admin
+func (a Actor) IsAdmin() bool {
+	return a.Role == models.RoleAdmin
+}
+
+// managesTeam reports whether the actor is the manager of the team
+func (a Actor) managesTeam(teamID int) bool {
+	return a.Role == models.RoleTeamManager && a.TeamID != 0 && a.TeamID == teamID
+}
+
+// CanManageTeams checks that the actor may create, update or delete teams
+func CanManageTeams(actor Actor) error {
+	if actor.IsAdmin() || actor.HasScope(config.ScopeWriteTeams) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanManagePlayers checks that the actor may create, update or delete players of every given
+// team. A player moved between teams must be manageable in both.
+func CanManagePlayers(actor Actor, teamIDs ...int) error {
+	if actor.IsAdmin() || actor.HasScope(config.ScopeWritePlayers) {
+		return nil
+	}
+	for _, teamID := range teamIDs {
+		if !actor.managesTeam(teamID) {
+			return ErrForbidden
+		}
+	}
+	return nil
+}
+
+// CanManageMatches checks that the actor may schedule, update or delete matches
+func CanManageMatches(actor Actor) error {
+	if actor.IsAdmin() || actor.HasScope(config.ScopeWriteMatches) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanSubmitResult checks that the actor may submit the final result of the match
+func CanSubmitResult(actor Actor, match *models.Match) error {
+	if actor.IsAdmin() || actor.HasScope(config.ScopeWriteResults) ||
+		actor.managesTeam(match.HomeTeamID) || actor.managesTeam(match.AwayTeamID) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanRecordGoals checks that the actor may record, correct or delete goals of the match.
+// Match officials and API keys only work on matches that are being played.
+func CanRecordGoals(actor Actor, match *models.Match) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	canRecord := actor.Role == models.RoleMatchOfficial || actor.HasScope(config.ScopeWriteGoals)
+	if canRecord && match.Status == models.StatusLive {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanManageUsers checks that the actor may list users and assign roles
+func CanManageUsers(actor Actor) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanManageTrash checks that the actor may list, restore or permanently delete deleted records
+func CanManageTrash(actor Actor) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanViewAudit checks that the actor may read the audit log
+func CanViewAudit(actor Actor) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanManageAPIKeys checks that the actor may issue, list or revoke API keys
+func CanManageAPIKeys(actor Actor) error {
+	if actor.IsAdmin() {
+		return nil
+	}
+	return ErrForbidden
+}
@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"testing"
+
+	"football-management-api/internal/config"
+	"football-management-api/internal/models"
+)
+
+var (
+	admin         = Actor{UserID: 1, Role: models.RoleAdmin}
+	manager       = Actor{UserID: 2, Role: models.RoleTeamManager, TeamID: 1}
+	unassigned    = Actor{UserID: 3, Role: models.RoleTeamManager}
+	official      = Actor{UserID: 4, Role: models.RoleMatchOfficial}
+	viewer        = Actor{UserID: 5, Role: models.RoleViewer}
+	teamsKey      = Actor{APIKeyID: 1, Scopes: []string{config.ScopeWriteTeams}}
+	playersKey    = Actor{APIKeyID: 2, Scopes: []string{config.ScopeWritePlayers}}
+	matchesKey    = Actor{APIKeyID: 3, Scopes: []string{config.ScopeWriteMatches, config.ScopeWriteResults}}
+	goalsKey      = Actor{APIKeyID: 4, Scopes: []string{config.ScopeWriteGoals}}
+	readOnlyKey   = Actor{APIKeyID: 5, Scopes: []string{config.ScopeReadReports}}
+	scopedManager = Actor{UserID: 6, Role: models.RoleTeamManager, TeamID: 1, Scopes: []string{config.ScopeWriteTeams}}
+)
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor Actor
+		scope string
+		want  bool
+	}{
+		{"granted scope", teamsKey, config.ScopeWriteTeams, true},
+		{"other scope", teamsKey, config.ScopeWritePlayers, false},
+		{"user without API key", scopedManager, config.ScopeWriteTeams, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.actor.HasScope(tt.scope); got != tt.want {
+				t.Errorf("HasScope(%q) = %v, want %v", tt.scope, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanManageTeams(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor Actor
+		allow bool
+	}{
+		{"admin", admin, true},
+		{"write:teams key", teamsKey, true},
+		{"team manager", manager, false},
+		{"team manager with scopes but no API key", scopedManager, false},
+		{"viewer", viewer, false},
+		{"read only key", readOnlyKey, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkAllowed(t, CanManageTeams(tt.actor), tt.allow)
+		})
+	}
+}
+
+func TestCanManagePlayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		actor   Actor
+		teamIDs []int
+		allow   bool
+	}{
+		{"admin", admin, []int{1, 2}, true},
+		{"write:players key", playersKey, []int{2}, true},
+		{"manager of the team", manager, []int{1}, true},
+		{"manager of another team", manager, []int{2}, false},
+		{"transfer out of the managed team", manager, []int{1, 2}, false},
+		{"manager without a team", unassigned, []int{0}, false},
+		{"match official", official, []int{1}, false},
+		{"write:teams key", teamsKey, []int{1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkAllowed(t, CanManagePlayers(tt.actor, tt.teamIDs...), tt.allow)
+		})
+	}
+}
+
+func TestCanManageMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		actor Actor
+		allow bool
+	}{
+		{"admin", admin, true},
+		{"write:matches key", matchesKey, true},
+		{"team manager", manager, false},
+		{"match official", official, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkAllowed(t, CanManageMatches(tt.actor), tt.allow)
+		})
+	}
+}
+
+func TestCanSubmitResult(t *testing.T) {
+	home := &models.Match{HomeTeamID: 1, AwayTeamID: 2}
+	other := &models.Match{HomeTeamID: 2, AwayTeamID: 3}
+	away := &models.Match{HomeTeamID: 3, AwayTeamID: 1}
+
+	tests := []struct {
+		name  string
+		actor Actor
+		match *models.Match
+		allow bool
+	}{
+		{"admin", admin, other, true},
+		{"write:results key", matchesKey, other, true},
+		{"manager of the home team", manager, home, true},
+		{"manager of the away team", manager, away, true},
+		{"manager of neither team", manager, other, false},
+		{"match official", official, home, false},
+		{"viewer", viewer, home, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkAllowed(t, CanSubmitResult(tt.actor, tt.match), tt.allow)
+		})
+	}
+}
+
+func TestCanRecordGoals(t *testing.T) {
+	live := &models.Match{HomeTeamID: 1, AwayTeamID: 2, Status: models.StatusLive}
+	completed := &models.Match{HomeTeamID: 1, AwayTeamID: 2, Status: models.StatusCompleted}
+
+	tests := []struct {
+		name  string
+		actor Actor
+		match *models.Match
+		allow bool
+	}{
+		{"admin on a completed match", admin, completed, true},
+		{"match official on a live match", official, live, true},
+		{"match official on a completed match", official, completed, false},
+		{"write:goals key on a live match", goalsKey, live, true},
+		{"write:goals key on a completed match", goalsKey, completed, false},
+		{"manager of a playing team", manager, live, false},
+		{"viewer", viewer, live, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkAllowed(t, CanRecordGoals(tt.actor, tt.match), tt.allow)
+		})
+	}
+}
+
+func TestAdminOnlyRules(t *testing.T) {
+	rules := map[string]func(Actor) error{
+		"CanManageUsers":   CanManageUsers,
+		"CanManageTrash":   CanManageTrash,
+		"CanViewAudit":     CanViewAudit,
+		"CanManageAPIKeys": CanManageAPIKeys,
+	}
+
+	for name, rule := range rules {
+		t.Run(name, func(t *testing.T) {
+			checkAllowed(t, rule(admin), true)
+			checkAllowed(t, rule(System), true)
+			for _, actor := range []Actor{manager, official, viewer, teamsKey, playersKey, matchesKey, goalsKey} {
+				checkAllowed(t, rule(actor), false)
+			}
+		})
+	}
+}
+
+// checkAllowed checks that a rule allowed the action, or denied it with ErrForbidden
+func checkAllowed(t *testing.T, err error, allow bool) {
+	t.Helper()
+	if allow && err != nil {
+		t.Errorf("error = %v, want allowed", err)
+	}
+	if !allow && err != ErrForbidden {
+		t.Errorf("error = %v, want %v", err, ErrForbidden)
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"database/sql"
+	"football-management-api/internal/models"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type APIKeyRepository interface {
+	Create(key *models.APIKey) error
+	FindByPrefix(prefix string) (*models.APIKey, error)
+	FindAll() ([]models.APIKey, error)
+	TouchLastUsed(id int) error
+	Revoke(id int) error
+}
+
+type apiKeyRepository struct {
+	db DBTX
+}
+
+func NewAPIKeyRepository(db DBTX) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_by, created_at, updated_at`
+
+// Create stores a new API key
+func (r *apiKeyRepository) Create(key *models.APIKey) error {
+	query := `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, expires_at, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`
+
+	now := time.Now()
+	return r.db.QueryRow(query,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		pq.Array(key.Scopes),
+		key.ExpiresAt,
+		key.CreatedBy,
+		now,
+		now,
+	).Scan(&key.ID, &key.CreatedAt, &key.UpdatedAt)
+}
+
+// FindByPrefix finds an API key by its prefix, returning nil when there is none
+func (r *apiKeyRepository) FindByPrefix(prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := scanAPIKey(r.db.QueryRow(`SELECT `+apiKeyColumns+` FROM api_keys WHERE prefix = $1`, prefix), &key)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// FindAll finds all API keys, newest first
+func (r *apiKeyRepository) FindAll() ([]models.APIKey, error) {
+	rows, err := r.db.Query(`SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC, id DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		var key models.APIKey
+		if err := scanAPIKey(rows, &key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// TouchLastUsed records that a key was used. The timestamp is written at most once a minute
+// so busy integrations do not write on every request.
+func (r *apiKeyRepository) TouchLastUsed(id int) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = $1
+		WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $3)
+	`
+
+	now := time.Now()
+	_, err := r.db.Exec(query, now, id, now.Add(-time.Minute))
+	return err
+}
+
+// Revoke revokes an API key
+func (r *apiKeyRepository) Revoke(id int) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// scanAPIKey scans the apiKeyColumns of a row
+func scanAPIKey(row rowScanner, key *models.APIKey) error {
+	return row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		pq.Array(&key.Scopes),
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+}
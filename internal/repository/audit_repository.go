@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"encoding/json"
+	"football-management-api/internal/models"
+	"time"
+)
+
+type AuditRepository interface {
+	Create(entry *models.AuditLog) error
+	FindAll(filter models.AuditFilter, limit, offset int) ([]models.AuditLog, int64, error)
+}
+
+type auditRepository struct {
+	db DBTX
+}
+
+func NewAuditRepository(db DBTX) AuditRepository {
+	return &auditRepository{db: db}
+}
+
+const auditColumns = `id, entity, entity_id, action, actor_user_id, actor_api_key_id, actor_name, request_id, changes, created_at`
+
+// Create stores an audit entry. Run it in the transaction of the change it records, so the
+// change and its entry are committed or rolled back together.
+func (r *auditRepository) Create(entry *models.AuditLog) error {
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_logs (entity, entity_id, action, actor_user_id, actor_api_key_id, actor_name, request_id, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`
+
+	return r.db.QueryRow(query,
+		entry.Entity,
+		entry.EntityID,
+		entry.Action,
+		entry.ActorUserID,
+		entry.ActorAPIKeyID,
+		entry.ActorName,
+		entry.RequestID,
+		changes,
+		time.Now(),
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+// FindAll finds audit entries matching the filter, newest first
+func (r *auditRepository) FindAll(filter models.AuditFilter, limit, offset int) ([]models.AuditLog, int64, error) {
+	qb := &queryBuilder{}
+	if filter.Entity != "" {
+		qb.where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID > 0 {
+		qb.where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Actor != "" {
+		qb.where("LOWER(actor_name) = LOWER(?)", filter.Actor)
+	}
+	if filter.DateFrom != "" {
+		qb.where("created_at >= ?::date", filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		qb.where("created_at < ?::date + 1", filter.DateTo)
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM audit_logs ` + qb.whereClause()
+	if err := r.db.QueryRow(countQuery, qb.args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + auditColumns + ` FROM audit_logs ` + qb.whereClause() +
+		` ORDER BY created_at DESC, id DESC ` + qb.limitClause(limit, offset)
+
+	rows, err := r.db.Query(query, qb.args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []models.AuditLog{}
+	for rows.Next() {
+		var entry models.AuditLog
+		var changes []byte
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.Entity,
+			&entry.EntityID,
+			&entry.Action,
+			&entry.ActorUserID,
+			&entry.ActorAPIKeyID,
+			&entry.ActorName,
+			&entry.RequestID,
+			&changes,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, total, rows.Err()
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"football-management-api/internal/apperror"
+
+	"github.com/lib/pq"
+)
+
+// ErrVersionConflict is returned when a write guarded by a row version did not apply because
+// the row was changed or deleted after it was read
+var ErrVersionConflict = apperror.Precondition("VERSION_CONFLICT", "data telah diubah oleh permintaan lain, muat ulang lalu coba lagi")
+
+// Errors returned when a record does not exist or was deleted
+var (
+	ErrTeamNotFound   = apperror.NotFound("TEAM_NOT_FOUND", "tim tidak ditemukan")
+	ErrPlayerNotFound = apperror.NotFound("PLAYER_NOT_FOUND", "pemain tidak ditemukan")
+	ErrMatchNotFound  = apperror.NotFound("MATCH_NOT_FOUND", "pertandingan tidak ditemukan")
+	ErrGoalNotFound   = apperror.NotFound("GOAL_NOT_FOUND", "gol tidak ditemukan")
+	ErrUserNotFound   = apperror.NotFound("USER_NOT_FOUND", "pengguna tidak ditemukan")
+	ErrAPIKeyNotFound = apperror.NotFound("API_KEY_NOT_FOUND", "API key tidak ditemukan atau sudah dicabut")
+	ErrTrashNotFound  = apperror.NotFound("TRASH_ITEM_NOT_FOUND", "data tidak ditemukan di trash")
+)
+
+// ErrInvalidCursor is returned when a pagination cursor does not fit the requested sort
+var ErrInvalidCursor = apperror.Validation("INVALID_CURSOR", "cursor tidak valid")
+
+// Errors returned when a write would duplicate a unique value of another record
+var (
+	ErrTeamNameTaken     = apperror.Conflict("TEAM_NAME_TAKEN", "nama tim sudah digunakan")
+	ErrJerseyNumberTaken = apperror.Conflict("JERSEY_NUMBER_TAKEN", "nomor punggung sudah digunakan oleh pemain lain di tim ini")
+	ErrUsernameTaken     = apperror.Conflict("USERNAME_TAKEN", "username sudah digunakan")
+	ErrEmailTaken        = apperror.Conflict("EMAIL_TAKEN", "email sudah terdaftar")
+	ErrDuplicate         = apperror.Conflict("DUPLICATE", "data yang sama sudah ada")
+)
+
+// ErrConcurrentWrite is returned when the database aborted a write because it collided with a
+// concurrent one (a deadlock or a serialization failure). The write did not apply and can be
+// retried as is.
+var ErrConcurrentWrite = apperror.Conflict("CONCURRENT_WRITE", "data sedang diubah oleh permintaan lain, coba lagi")
+
+// uniqueViolations maps unique indexes to the error returned when a write violates them
+var uniqueViolations = map[string]error{
+	"unique_team_name":       ErrTeamNameTaken,
+	"unique_jersey_per_team": ErrJerseyNumberTaken,
+	"idx_users_username":     ErrUsernameTaken,
+	"idx_users_email":        ErrEmailTaken,
+}
+
+// Postgres error codes translated into conflicts
+const (
+	pqUniqueViolation      = "23505"
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+)
+
+// translateError turns a unique violation into a conflict. Services check for duplicates
+// before writing, but two concurrent requests can both pass that check; the index then
+// rejects the second write. A deadlock or serialization failure, which aborts one of two
+// concurrent writes, becomes ErrConcurrentWrite. Other errors are returned unchanged.
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		if mapped, ok := uniqueViolations[pqErr.Constraint]; ok {
+			return mapped
+		}
+		return ErrDuplicate
+	case pqSerializationFailure, pqDeadlockDetected:
+		return ErrConcurrentWrite
+	}
+	return err
+}
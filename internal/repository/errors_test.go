@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestTranslateError(t *testing.T) {
+	other := errors.New("connection refused")
+	foreignKey := &pq.Error{Code: "23503", Constraint: "players_team_id_fkey"}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"nil", nil, nil},
+		{"team name", &pq.Error{Code: pqUniqueViolation, Constraint: "unique_team_name"}, ErrTeamNameTaken},
+		{"jersey number", &pq.Error{Code: pqUniqueViolation, Constraint: "unique_jersey_per_team"}, ErrJerseyNumberTaken},
+		{"username", &pq.Error{Code: pqUniqueViolation, Constraint: "idx_users_username"}, ErrUsernameTaken},
+		{"email", &pq.Error{Code: pqUniqueViolation, Constraint: "idx_users_email"}, ErrEmailTaken},
+		{"wrapped", fmt.Errorf("insert team: %w", &pq.Error{Code: pqUniqueViolation, Constraint: "unique_team_name"}), ErrTeamNameTaken},
+		{"unknown unique index", &pq.Error{Code: pqUniqueViolation, Constraint: "api_keys_prefix_key"}, ErrDuplicate},
+		{"deadlock", &pq.Error{Code: pqDeadlockDetected}, ErrConcurrentWrite},
+		{"serialization failure", &pq.Error{Code: pqSerializationFailure}, ErrConcurrentWrite},
+		{"wrapped deadlock", fmt.Errorf("commit: %w", &pq.Error{Code: pqDeadlockDetected}), ErrConcurrentWrite},
+		{"other database error", foreignKey, foreignKey},
+		{"other error", other, other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := translateError(tt.err); got != tt.want {
+				t.Errorf("translateError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
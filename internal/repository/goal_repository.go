@@ -2,41 +2,52 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
+	"fmt"
 	"football-management-api/internal/models"
+	"regexp"
+	"strconv"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type GoalRepository interface {
 	Create(goal *models.Goal) error
 	FindByID(id int) (*models.Goal, error)
+	FindAll(filter models.GoalFilter, opts models.ListOptions) ([]models.Goal, models.PageInfo, error)
 	FindByMatchID(matchID int) ([]models.Goal, error)
-	Delete(id int) error
+	FindByMatchIDs(matchIDs []int) ([]models.Goal, error)
+	FindByPlayerIDs(playerIDs []int) ([]models.Goal, error)
+	FindByPlayerIDInMatches(playerID int, matchIDs []int) ([]models.Goal, error)
+	Update(id int, goal *models.Goal) error
+	Delete(id, version int) error
 	DeleteByMatchID(matchID int) error
 	FindTopScorerInMatch(matchID int) (*models.TopScorerInfo, error)
 }
 
 type goalRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewGoalRepository(db *sql.DB) GoalRepository {
+func NewGoalRepository(db DBTX) GoalRepository {
 	return &goalRepository{db: db}
 }
 
 // Create creates a new goal
 func (r *goalRepository) Create(goal *models.Goal) error {
 	query := `
-		INSERT INTO goals (match_id, player_id, goal_time, created_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO goals (match_id, player_id, team_id, goal_time, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id
 	`
 
 	err := r.db.QueryRow(query,
 		goal.MatchID,
 		goal.PlayerID,
+		goal.TeamID,
 		goal.GoalTime,
 		time.Now(),
+		time.Now(),
 	).Scan(&goal.ID)
 
 	if err != nil {
@@ -49,11 +60,11 @@ func (r *goalRepository) Create(goal *models.Goal) error {
 // FindByID finds a goal by ID
 func (r *goalRepository) FindByID(id int) (*models.Goal, error) {
 	query := `
-		SELECT g.id, g.match_id, g.player_id, g.goal_time, g.created_at,
+		SELECT g.id, g.match_id, g.player_id, g.team_id, g.goal_time, g.version, g.created_at, g.updated_at,
 		       p.name, t.name
 		FROM goals g
 		LEFT JOIN players p ON g.player_id = p.id
-		LEFT JOIN teams t ON p.team_id = t.id
+		LEFT JOIN teams t ON g.team_id = t.id
 		WHERE g.id = $1 AND g.deleted_at IS NULL
 	`
 
@@ -65,14 +76,17 @@ func (r *goalRepository) FindByID(id int) (*models.Goal, error) {
 		&goal.ID,
 		&goal.MatchID,
 		&goal.PlayerID,
+		&goal.TeamID,
 		&goal.GoalTime,
+		&goal.Version,
 		&goal.CreatedAt,
+		&goal.UpdatedAt,
 		&player.Name,
 		&team.Name,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, errors.New("gol tidak ditemukan")
+		return nil, ErrGoalNotFound
 	}
 
 	if err != nil {
@@ -85,16 +99,145 @@ func (r *goalRepository) FindByID(id int) (*models.Goal, error) {
 	return &goal, nil
 }
 
+// goalSortColumns maps the sort keys accepted by the goal list to their columns
+var goalSortColumns = map[string]string{
+	"goal_time":  "g.minute, g.stoppage_minute",
+	"player":     "p.name",
+	"created_at": "g.created_at",
+}
+
+// goalDefaultSort orders the goal list when no sort is requested
+var goalDefaultSort = []models.SortField{{Field: "created_at", Desc: true}}
+
+// FindAll finds goals matching the filter with search, sorting and offset or keyset pagination
+func (r *goalRepository) FindAll(filter models.GoalFilter, opts models.ListOptions) ([]models.Goal, models.PageInfo, error) {
+	var page models.PageInfo
+	keys := resolveSort(opts.Sort, goalSortColumns, goalDefaultSort, "g.id")
+
+	qb := &queryBuilder{}
+	qb.where("g.deleted_at IS NULL")
+
+	if filter.MatchID > 0 {
+		qb.where("g.match_id = ?", filter.MatchID)
+	}
+	if filter.PlayerID > 0 {
+		qb.where("g.player_id = ?", filter.PlayerID)
+	}
+	if filter.TeamID > 0 {
+		qb.where("g.team_id = ?", filter.TeamID)
+	}
+	if opts.Search != "" {
+		qb.where("p.name ILIKE ?", searchPattern(opts.Search))
+	}
+
+	from := `
+		FROM goals g
+		LEFT JOIN players p ON g.player_id = p.id
+		LEFT JOIN teams t ON g.team_id = t.id
+	`
+
+	if opts.Cursor == nil {
+		// Get total count
+		countQuery := "SELECT COUNT(*) " + from + qb.whereClause()
+		err := r.db.QueryRow(countQuery, qb.args...).Scan(&page.Total)
+		if err != nil {
+			return nil, page, err
+		}
+	} else if err := qb.keyset(keys, opts.Cursor); err != nil {
+		return nil, page, err
+	}
+
+	// Get goals
+	query := fmt.Sprintf(`
+		SELECT g.id, g.match_id, g.player_id, g.team_id, g.goal_time, g.created_at, g.updated_at,
+		       p.name, t.name
+		%s
+		%s
+		%s
+		%s
+	`, from, qb.whereClause(), orderByClause(keys, isReversed(opts)), qb.pageClause(opts))
+
+	rows, err := r.db.Query(query, qb.args...)
+	if err != nil {
+		return nil, page, err
+	}
+	defer rows.Close()
+
+	var goals []models.Goal
+	for rows.Next() {
+		var goal models.Goal
+		var playerName, teamName string
+
+		err := rows.Scan(
+			&goal.ID,
+			&goal.MatchID,
+			&goal.PlayerID,
+			&goal.TeamID,
+			&goal.GoalTime,
+			&goal.CreatedAt,
+			&goal.UpdatedAt,
+			&playerName,
+			&teamName,
+		)
+		if err != nil {
+			return nil, page, err
+		}
+
+		goal.Player = &models.Player{Name: playerName, Team: &models.Team{Name: teamName}}
+		goals = append(goals, goal)
+	}
+
+	if opts.Cursor != nil {
+		goals, page = keysetPage(goals, keys, opts, goalColumnValue)
+	}
+
+	return goals, page, nil
+}
+
+// goalColumnValue returns a goal's value for a sort column, used to build cursors
+func goalColumnValue(goal *models.Goal, column string) interface{} {
+	switch column {
+	case "g.minute":
+		minute, _ := goalMinute(goal.GoalTime)
+		return minute
+	case "g.stoppage_minute":
+		_, stoppage := goalMinute(goal.GoalTime)
+		return stoppage
+	case "p.name":
+		return goal.Player.Name
+	case "g.created_at":
+		return goal.CreatedAt
+	default:
+		return goal.ID
+	}
+}
+
+// The patterns the minute and stoppage_minute columns are generated from goal_time with
+var (
+	goalMinutePattern   = regexp.MustCompile(`^[0-9]+`)
+	goalStoppagePattern = regexp.MustCompile(`\+([0-9]+)`)
+)
+
+// goalMinute returns the minute and stoppage minute of a goal time such as "45+2" the way the
+// database derives them, so cursors hold the same values the goals are sorted by
+func goalMinute(goalTime string) (minute, stoppage int) {
+	minute, _ = strconv.Atoi(goalMinutePattern.FindString(goalTime))
+	if match := goalStoppagePattern.FindStringSubmatch(goalTime); match != nil {
+		stoppage, _ = strconv.Atoi(match[1])
+	}
+	return minute, stoppage
+}
+
 // FindByMatchID finds all goals in a match
 func (r *goalRepository) FindByMatchID(matchID int) ([]models.Goal, error) {
 	query := `
-		SELECT g.id, g.match_id, g.player_id, g.goal_time, g.created_at,
+		SELECT g.id, g.match_id, g.player_id, g.team_id, g.goal_time, g.created_at, g.updated_at,
 		       p.name, t.name
 		FROM goals g
 		LEFT JOIN players p ON g.player_id = p.id
-		LEFT JOIN teams t ON p.team_id = t.id
+		LEFT JOIN teams t ON g.team_id = t.id
 		WHERE g.match_id = $1 AND g.deleted_at IS NULL
-		ORDER BY g.goal_time ASC
+		ORDER BY g.minute ASC, g.stoppage_minute ASC, g.id ASC
 	`
 
 	rows, err := r.db.Query(query, matchID)
@@ -112,8 +255,65 @@ func (r *goalRepository) FindByMatchID(matchID int) ([]models.Goal, error) {
 			&goal.ID,
 			&goal.MatchID,
 			&goal.PlayerID,
+			&goal.TeamID,
+			&goal.GoalTime,
+			&goal.CreatedAt,
+			&goal.UpdatedAt,
+			&playerName,
+			&teamName,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		goal.Player = &models.Player{Name: playerName, Team: &models.Team{Name: teamName}}
+		goals = append(goals, goal)
+	}
+
+	return goals, nil
+}
+
+// FindByMatchIDs finds all goals in the given matches with a single query
+func (r *goalRepository) FindByMatchIDs(matchIDs []int) ([]models.Goal, error) {
+	return r.findWithScorer("g.match_id = ANY($1)", pq.Array(matchIDs))
+}
+
+// FindByPlayerIDs finds all goals of the given players with a single query
+func (r *goalRepository) FindByPlayerIDs(playerIDs []int) ([]models.Goal, error) {
+	return r.findWithScorer("g.player_id = ANY($1)", pq.Array(playerIDs))
+}
+
+// findWithScorer finds goals matching the condition together with the scorer and team names
+func (r *goalRepository) findWithScorer(condition string, args ...interface{}) ([]models.Goal, error) {
+	query := `
+		SELECT g.id, g.match_id, g.player_id, g.team_id, g.goal_time, g.created_at, g.updated_at,
+		       p.name, t.name
+		FROM goals g
+		LEFT JOIN players p ON g.player_id = p.id
+		LEFT JOIN teams t ON g.team_id = t.id
+		WHERE ` + condition + ` AND g.deleted_at IS NULL
+		ORDER BY g.match_id ASC, g.minute ASC, g.stoppage_minute ASC, g.id ASC
+	`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var goals []models.Goal
+	for rows.Next() {
+		var goal models.Goal
+		var playerName, teamName string
+
+		err := rows.Scan(
+			&goal.ID,
+			&goal.MatchID,
+			&goal.PlayerID,
+			&goal.TeamID,
 			&goal.GoalTime,
 			&goal.CreatedAt,
+			&goal.UpdatedAt,
 			&playerName,
 			&teamName,
 		)
@@ -128,15 +328,77 @@ func (r *goalRepository) FindByMatchID(matchID int) ([]models.Goal, error) {
 	return goals, nil
 }
 
-// Delete soft deletes a goal
-func (r *goalRepository) Delete(id int) error {
+// FindByPlayerIDInMatches finds a player's goals in the given matches with a single query
+func (r *goalRepository) FindByPlayerIDInMatches(playerID int, matchIDs []int) ([]models.Goal, error) {
+	query := `
+		SELECT id, match_id, player_id, team_id, goal_time, created_at, updated_at
+		FROM goals
+		WHERE player_id = $1 AND match_id = ANY($2) AND deleted_at IS NULL
+		ORDER BY match_id ASC, minute ASC, stoppage_minute ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, playerID, pq.Array(matchIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var goals []models.Goal
+	for rows.Next() {
+		var goal models.Goal
+		err := rows.Scan(
+			&goal.ID,
+			&goal.MatchID,
+			&goal.PlayerID,
+			&goal.TeamID,
+			&goal.GoalTime,
+			&goal.CreatedAt,
+			&goal.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, goal)
+	}
+
+	return goals, nil
+}
+
+// Update updates a goal if it still has the version it was read with
+func (r *goalRepository) Update(id int, goal *models.Goal) error {
 	query := `
 		UPDATE goals
-		SET deleted_at = $1
-		WHERE id = $2 AND deleted_at IS NULL
+		SET player_id = $1, team_id = $2, goal_time = $3, updated_at = $4, version = version + 1
+		WHERE id = $5 AND version = $6 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, goal.PlayerID, goal.TeamID, goal.GoalTime, time.Now(), id, goal.Version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// The row was changed or deleted since it was read
+	if rowsAffected == 0 {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
+
+// Delete soft deletes a goal if it still has the given version
+func (r *goalRepository) Delete(id, version int) error {
+	query := `
+		UPDATE goals
+		SET deleted_at = $1, version = version + 1
+		WHERE id = $2 AND version = $3 AND deleted_at IS NULL
 	`
 
-	result, err := r.db.Exec(query, time.Now(), id)
+	result, err := r.db.Exec(query, time.Now(), id, version)
 	if err != nil {
 		return err
 	}
@@ -146,8 +408,9 @@ func (r *goalRepository) Delete(id int) error {
 		return err
 	}
 
+	// The row was changed or deleted since it was read
 	if rowsAffected == 0 {
-		return errors.New("gol tidak ditemukan")
+		return ErrVersionConflict
 	}
 
 	return nil
@@ -171,7 +434,7 @@ func (r *goalRepository) FindTopScorerInMatch(matchID int) (*models.TopScorerInf
 		SELECT p.id, p.name, t.name, COUNT(*) as goals_count
 		FROM goals g
 		JOIN players p ON g.player_id = p.id
-		JOIN teams t ON p.team_id = t.id
+		JOIN teams t ON g.team_id = t.id
 		WHERE g.match_id = $1 AND g.deleted_at IS NULL
 		GROUP BY p.id, p.name, t.name
 		ORDER BY goals_count DESC
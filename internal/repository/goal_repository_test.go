@@ -0,0 +1,41 @@
+package repository
+
+import "testing"
+
+func TestGoalMinute(t *testing.T) {
+	tests := []struct {
+		goalTime     string
+		wantMinute   int
+		wantStoppage int
+	}{
+		{"9", 9, 0},
+		{"10", 10, 0},
+		{"45+2", 45, 2},
+		{"90+10", 90, 10},
+		{"45'", 45, 0},
+		{"", 0, 0},
+		{"abc", 0, 0},
+		{"+3", 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goalTime, func(t *testing.T) {
+			minute, stoppage := goalMinute(tt.goalTime)
+			if minute != tt.wantMinute || stoppage != tt.wantStoppage {
+				t.Errorf("goalMinute(%q) = %d, %d, want %d, %d", tt.goalTime, minute, stoppage, tt.wantMinute, tt.wantStoppage)
+			}
+		})
+	}
+}
+
+func TestGoalMinuteOrdersNumerically(t *testing.T) {
+	times := []string{"9", "10", "45", "45+1", "45+2", "46", "90+10"}
+
+	for i := 1; i < len(times); i++ {
+		prevMinute, prevStoppage := goalMinute(times[i-1])
+		minute, stoppage := goalMinute(times[i])
+		if prevMinute > minute || (prevMinute == minute && prevStoppage >= stoppage) {
+			t.Errorf("goal time %q does not sort before %q", times[i-1], times[i])
+		}
+	}
+}
@@ -2,27 +2,34 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
+	"football-management-api/internal/config"
 	"football-management-api/internal/models"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type MatchRepository interface {
 	Create(match *models.Match) error
 	FindByID(id int) (*models.Match, error)
-	FindAll(limit, offset int) ([]models.Match, int64, error)
-	FindByTeamID(teamID int) ([]models.Match, error)
+	FindAll(filter models.MatchFilter, opts models.ListOptions) ([]models.Match, models.PageInfo, error)
+	FindByTeamID(teamID int, filter models.MatchFilter, limit, offset int) ([]models.Match, int64, error)
+	FindByTeamIDs(teamIDs []int) ([]models.Match, error)
+	FindByPlayerID(playerID, teamID int, filter models.MatchFilter, limit, offset int) ([]models.Match, int64, error)
 	Update(id int, match *models.Match) error
-	UpdateResult(id int, homeScore, awayScore int, status models.MatchStatus) error
-	Delete(id int) error
+	UpdateResult(id, version int, homeScore, awayScore int, status models.MatchStatus) error
+	RecalculateScore(id int) error
+	ClearScore(id int) error
+	Delete(id, version int) error
 	FindCompletedMatches() ([]models.Match, error)
 }
 
 type matchRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewMatchRepository(db *sql.DB) MatchRepository {
+func NewMatchRepository(db DBTX) MatchRepository {
 	return &matchRepository{db: db}
 }
 
@@ -55,7 +62,7 @@ func (r *matchRepository) Create(match *models.Match) error {
 func (r *matchRepository) FindByID(id int) (*models.Match, error) {
 	query := `
 		SELECT m.id, m.match_date, m.match_time, m.home_team_id, m.away_team_id, 
-		       m.home_score, m.away_score, m.status, m.created_at, m.updated_at,
+		       m.home_score, m.away_score, m.status, m.version, m.created_at, m.updated_at,
 		       ht.id, ht.name, ht.logo_url, ht.home_city,
 		       at.id, at.name, at.logo_url, at.home_city
 		FROM matches m
@@ -65,8 +72,7 @@ func (r *matchRepository) FindByID(id int) (*models.Match, error) {
 	`
 
 	var match models.Match
-	var homeTeam, awayTeam models.Team
-	var homeLogoURL, awayLogoURL sql.NullString
+	var homeTeam, awayTeam joinedTeam
 
 	err := r.db.QueryRow(query, id).Scan(
 		&match.ID,
@@ -77,46 +83,50 @@ func (r *matchRepository) FindByID(id int) (*models.Match, error) {
 		&match.HomeScore,
 		&match.AwayScore,
 		&match.Status,
+		&match.Version,
 		&match.CreatedAt,
 		&match.UpdatedAt,
 		&homeTeam.ID,
 		&homeTeam.Name,
-		&homeLogoURL,
+		&homeTeam.LogoURL,
 		&homeTeam.HomeCity,
 		&awayTeam.ID,
 		&awayTeam.Name,
-		&awayLogoURL,
+		&awayTeam.LogoURL,
 		&awayTeam.HomeCity,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, errors.New("pertandingan tidak ditemukan")
+		return nil, ErrMatchNotFound
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	homeTeam.LogoURL = homeLogoURL
-	awayTeam.LogoURL = awayLogoURL
-	match.HomeTeam = &homeTeam
-	match.AwayTeam = &awayTeam
+	match.HomeTeam = homeTeam.team()
+	match.AwayTeam = awayTeam.team()
 
 	return &match, nil
 }
 
-// FindAll finds all matches with pagination
-func (r *matchRepository) FindAll(limit, offset int) ([]models.Match, int64, error) {
-	// Get total count
-	var total int64
-	countQuery := "SELECT COUNT(*) FROM matches WHERE deleted_at IS NULL"
-	err := r.db.QueryRow(countQuery).Scan(&total)
-	if err != nil {
-		return nil, 0, err
-	}
+// Sort columns of the team names, which are NULL when the team was deleted
+const (
+	matchHomeTeamSortColumn = "COALESCE(ht.name, '')"
+	matchAwayTeamSortColumn = "COALESCE(at.name, '')"
+)
 
-	// Get matches
-	query := `
+// matchSortColumns maps the sort keys accepted by match lists to their columns
+var matchSortColumns = map[string]string{
+	"match_date": "m.match_date, m.match_time",
+	"status":     "m.status",
+	"home_team":  matchHomeTeamSortColumn,
+	"away_team":  matchAwayTeamSortColumn,
+	"created_at": "m.created_at",
+}
+
+// matchesWithTeamsQuery selects matches joined with their home and away teams
+const matchesWithTeamsQuery = `
 		SELECT m.id, m.match_date, m.match_time, m.home_team_id, m.away_team_id,
 		       m.home_score, m.away_score, m.status, m.created_at, m.updated_at,
 		       ht.id, ht.name, ht.logo_url, ht.home_city,
@@ -124,76 +134,184 @@ func (r *matchRepository) FindAll(limit, offset int) ([]models.Match, int64, err
 		FROM matches m
 		LEFT JOIN teams ht ON m.home_team_id = ht.id AND ht.deleted_at IS NULL
 		LEFT JOIN teams at ON m.away_team_id = at.id AND at.deleted_at IS NULL
-		WHERE m.deleted_at IS NULL
-		ORDER BY m.match_date DESC, m.match_time DESC
-		LIMIT $1 OFFSET $2
 	`
 
-	rows, err := r.db.Query(query, limit, offset)
+// matchDefaultSort orders the match list when no sort is requested
+var matchDefaultSort = []models.SortField{{Field: "match_date", Desc: true}}
+
+// FindAll finds matches matching the filter with search, sorting and offset or keyset pagination
+func (r *matchRepository) FindAll(filter models.MatchFilter, opts models.ListOptions) ([]models.Match, models.PageInfo, error) {
+	var page models.PageInfo
+	keys := resolveSort(opts.Sort, matchSortColumns, matchDefaultSort, "m.id")
+
+	qb := &queryBuilder{}
+	qb.where("m.deleted_at IS NULL")
+	applyMatchFilter(qb, filter)
+
+	if opts.Search != "" {
+		qb.where("(ht.name ILIKE ? OR at.name ILIKE ?)", searchPattern(opts.Search), searchPattern(opts.Search))
+	}
+
+	if opts.Cursor == nil {
+		total, err := r.countMatches(qb)
+		if err != nil {
+			return nil, page, err
+		}
+		page.Total = total
+	} else if err := qb.keyset(keys, opts.Cursor); err != nil {
+		return nil, page, err
+	}
+
+	matches, err := r.queryMatches(qb, orderByClause(keys, isReversed(opts)), qb.pageClause(opts))
 	if err != nil {
-		return nil, 0, err
+		return nil, page, err
 	}
-	defer rows.Close()
 
-	var matches []models.Match
-	for rows.Next() {
-		var match models.Match
-		var homeTeam, awayTeam models.Team
-		var homeLogoURL, awayLogoURL sql.NullString
+	if opts.Cursor != nil {
+		matches, page = keysetPage(matches, keys, opts, matchColumnValue)
+	}
 
-		err := rows.Scan(
-			&match.ID,
-			&match.MatchDate,
-			&match.MatchTime,
-			&match.HomeTeamID,
-			&match.AwayTeamID,
-			&match.HomeScore,
-			&match.AwayScore,
-			&match.Status,
-			&match.CreatedAt,
-			&match.UpdatedAt,
-			&homeTeam.ID,
-			&homeTeam.Name,
-			&homeLogoURL,
-			&homeTeam.HomeCity,
-			&awayTeam.ID,
-			&awayTeam.Name,
-			&awayLogoURL,
-			&awayTeam.HomeCity,
-		)
-		if err != nil {
-			return nil, 0, err
+	return matches, page, nil
+}
+
+// matchColumnValue returns a match's value for a sort column, used to build cursors
+func matchColumnValue(match *models.Match, column string) interface{} {
+	switch column {
+	case "m.match_date":
+		return match.MatchDate
+	case "m.match_time":
+		return match.MatchTime
+	case "m.status":
+		return match.Status
+	case matchHomeTeamSortColumn:
+		if match.HomeTeam == nil {
+			return ""
+		}
+		return match.HomeTeam.Name
+	case matchAwayTeamSortColumn:
+		if match.AwayTeam == nil {
+			return ""
 		}
+		return match.AwayTeam.Name
+	case "m.created_at":
+		return match.CreatedAt
+	default:
+		return match.ID
+	}
+}
 
-		homeTeam.LogoURL = homeLogoURL
-		awayTeam.LogoURL = awayLogoURL
-		match.HomeTeam = &homeTeam
-		match.AwayTeam = &awayTeam
-		matches = append(matches, match)
+// FindByTeamID finds matches of a team with optional filters. A limit of 0 returns all matches.
+func (r *matchRepository) FindByTeamID(teamID int, filter models.MatchFilter, limit, offset int) ([]models.Match, int64, error) {
+	qb := &queryBuilder{}
+	qb.where("m.deleted_at IS NULL")
+
+	switch filter.Venue {
+	case config.VenueHome:
+		qb.where("m.home_team_id = ?", teamID)
+	case config.VenueAway:
+		qb.where("m.away_team_id = ?", teamID)
+	default:
+		qb.where("(m.home_team_id = ? OR m.away_team_id = ?)", teamID, teamID)
+	}
+
+	order := "ORDER BY m.match_date DESC, m.match_time DESC"
+	switch filter.Period {
+	case config.PeriodUpcoming:
+		qb.where("m.status = 'Scheduled' AND m.match_date >= CURRENT_DATE")
+		order = "ORDER BY m.match_date ASC, m.match_time ASC"
+	case config.PeriodPast:
+		qb.where("(m.status IN ('Completed', 'Cancelled') OR m.match_date < CURRENT_DATE)")
+	}
+
+	applyMatchFilter(qb, filter)
+
+	return r.findMatches(qb, order, limit, offset)
+}
+
+// FindByTeamIDs finds all matches of the given teams with a single query
+func (r *matchRepository) FindByTeamIDs(teamIDs []int) ([]models.Match, error) {
+	qb := &queryBuilder{}
+	qb.where("m.deleted_at IS NULL")
+	qb.where("(m.home_team_id = ANY(?) OR m.away_team_id = ANY(?))", pq.Array(teamIDs), pq.Array(teamIDs))
+
+	return r.queryMatches(qb, "ORDER BY m.match_date DESC, m.match_time DESC", "")
+}
+
+// FindByPlayerID finds matches of the player's current team and matches the player scored in,
+// with pagination
+func (r *matchRepository) FindByPlayerID(playerID, teamID int, filter models.MatchFilter, limit, offset int) ([]models.Match, int64, error) {
+	qb := &queryBuilder{}
+	qb.where("m.deleted_at IS NULL")
+	qb.where(`(m.home_team_id = ? OR m.away_team_id = ? OR EXISTS (
+			SELECT 1 FROM goals g WHERE g.match_id = m.id AND g.player_id = ? AND g.deleted_at IS NULL
+		))`, teamID, teamID, playerID)
+	applyMatchFilter(qb, filter)
+
+	return r.findMatches(qb, "ORDER BY m.match_date DESC, m.match_time DESC", limit, offset)
+}
+
+// findMatches counts and loads the matches selected by the query builder
+func (r *matchRepository) findMatches(qb *queryBuilder, order string, limit, offset int) ([]models.Match, int64, error) {
+	total, err := r.countMatches(qb)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	matches, err := r.queryMatches(qb, order, qb.limitClause(limit, offset))
+	if err != nil {
+		return nil, 0, err
 	}
 
 	return matches, total, nil
 }
 
-// FindByTeamID finds all matches for a team
-func (r *matchRepository) FindByTeamID(teamID int) ([]models.Match, error) {
-	query := `
-		SELECT m.id, m.match_date, m.match_time, m.home_team_id, m.away_team_id,
-		       m.home_score, m.away_score, m.status, m.created_at, m.updated_at
+// countMatches counts the matches selected by the query builder
+func (r *matchRepository) countMatches(qb *queryBuilder) (int64, error) {
+	var total int64
+	countQuery := `
+		SELECT COUNT(*)
 		FROM matches m
-		WHERE (m.home_team_id = $1 OR m.away_team_id = $2) AND m.deleted_at IS NULL
-		ORDER BY m.match_date DESC, m.match_time DESC
-	`
+		LEFT JOIN teams ht ON m.home_team_id = ht.id AND ht.deleted_at IS NULL
+		LEFT JOIN teams at ON m.away_team_id = at.id AND at.deleted_at IS NULL
+	` + qb.whereClause()
+	err := r.db.QueryRow(countQuery, qb.args...).Scan(&total)
+	return total, err
+}
 
-	rows, err := r.db.Query(query, teamID, teamID)
+// queryMatches loads the matches selected by the query builder. The limit clause must be
+// built before calling so its arguments are bound.
+func (r *matchRepository) queryMatches(qb *queryBuilder, order, limit string) ([]models.Match, error) {
+	query := strings.Join([]string{matchesWithTeamsQuery, qb.whereClause(), order, limit}, "\n")
+
+	rows, err := r.db.Query(query, qb.args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanMatchesWithTeams(rows)
+}
+
+// applyMatchFilter adds the status and date range conditions of a filter
+func applyMatchFilter(qb *queryBuilder, filter models.MatchFilter) {
+	if filter.Status != "" {
+		qb.where("m.status = ?", filter.Status)
+	}
+	if filter.DateFrom != "" {
+		qb.where("m.match_date >= ?", filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		qb.where("m.match_date <= ?", filter.DateTo)
+	}
+}
+
+// scanMatchesWithTeams scans match rows joined with their home and away teams
+func scanMatchesWithTeams(rows *sql.Rows) ([]models.Match, error) {
 	var matches []models.Match
 	for rows.Next() {
 		var match models.Match
+		var homeTeam, awayTeam joinedTeam
+
 		err := rows.Scan(
 			&match.ID,
 			&match.MatchDate,
@@ -205,22 +323,33 @@ func (r *matchRepository) FindByTeamID(teamID int) ([]models.Match, error) {
 			&match.Status,
 			&match.CreatedAt,
 			&match.UpdatedAt,
+			&homeTeam.ID,
+			&homeTeam.Name,
+			&homeTeam.LogoURL,
+			&homeTeam.HomeCity,
+			&awayTeam.ID,
+			&awayTeam.Name,
+			&awayTeam.LogoURL,
+			&awayTeam.HomeCity,
 		)
 		if err != nil {
 			return nil, err
 		}
+
+		match.HomeTeam = homeTeam.team()
+		match.AwayTeam = awayTeam.team()
 		matches = append(matches, match)
 	}
 
 	return matches, nil
 }
 
-// Update updates a match
+// Update updates a match if it still has the version it was read with
 func (r *matchRepository) Update(id int, match *models.Match) error {
 	query := `
 		UPDATE matches
-		SET match_date = $1, match_time = $2, home_team_id = $3, away_team_id = $4, status = $5, updated_at = $6
-		WHERE id = $7 AND deleted_at IS NULL
+		SET match_date = $1, match_time = $2, home_team_id = $3, away_team_id = $4, status = $5, updated_at = $6, version = version + 1
+		WHERE id = $7 AND version = $8 AND deleted_at IS NULL
 	`
 
 	result, err := r.db.Exec(query,
@@ -231,6 +360,7 @@ func (r *matchRepository) Update(id int, match *models.Match) error {
 		match.Status,
 		time.Now(),
 		id,
+		match.Version,
 	)
 
 	if err != nil {
@@ -242,22 +372,23 @@ func (r *matchRepository) Update(id int, match *models.Match) error {
 		return err
 	}
 
+	// The row was changed or deleted since it was read
 	if rowsAffected == 0 {
-		return errors.New("pertandingan tidak ditemukan")
+		return ErrVersionConflict
 	}
 
 	return nil
 }
 
-// UpdateResult updates match result
-func (r *matchRepository) UpdateResult(id int, homeScore, awayScore int, status models.MatchStatus) error {
+// UpdateResult updates match result if the match still has the given version
+func (r *matchRepository) UpdateResult(id, version int, homeScore, awayScore int, status models.MatchStatus) error {
 	query := `
 		UPDATE matches
-		SET home_score = $1, away_score = $2, status = $3, updated_at = $4
-		WHERE id = $5 AND deleted_at IS NULL
+		SET home_score = $1, away_score = $2, status = $3, updated_at = $4, version = version + 1
+		WHERE id = $5 AND version = $6 AND deleted_at IS NULL
 	`
 
-	result, err := r.db.Exec(query, homeScore, awayScore, status, time.Now(), id)
+	result, err := r.db.Exec(query, homeScore, awayScore, status, time.Now(), id, version)
 	if err != nil {
 		return err
 	}
@@ -267,18 +398,55 @@ func (r *matchRepository) UpdateResult(id int, homeScore, awayScore int, status
 		return err
 	}
 
+	// The row was changed or deleted since it was read
 	if rowsAffected == 0 {
-		return errors.New("pertandingan tidak ditemukan")
+		return ErrVersionConflict
 	}
 
 	return nil
 }
 
-// Delete soft deletes a match
-func (r *matchRepository) Delete(id int) error {
+// RecalculateScore sets the match score from the goals recorded for each team
+func (r *matchRepository) RecalculateScore(id int) error {
+	query := `
+		UPDATE matches m
+		SET home_score = (
+				SELECT COUNT(*)
+				FROM goals g
+				WHERE g.match_id = m.id AND g.deleted_at IS NULL AND g.team_id = m.home_team_id
+			),
+			away_score = (
+				SELECT COUNT(*)
+				FROM goals g
+				WHERE g.match_id = m.id AND g.deleted_at IS NULL AND g.team_id = m.away_team_id
+			),
+			updated_at = $1,
+			version = m.version + 1
+		WHERE m.id = $2 AND m.deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrMatchNotFound
+	}
+
+	return nil
+}
+
+// ClearScore resets the score of a match that has not started to NULL, i.e. not played yet
+func (r *matchRepository) ClearScore(id int) error {
 	query := `
 		UPDATE matches
-		SET deleted_at = $1
+		SET home_score = NULL, away_score = NULL, updated_at = $1, version = version + 1
 		WHERE id = $2 AND deleted_at IS NULL
 	`
 
@@ -293,7 +461,33 @@ func (r *matchRepository) Delete(id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("pertandingan tidak ditemukan")
+		return ErrMatchNotFound
+	}
+
+	return nil
+}
+
+// Delete soft deletes a match if it still has the given version
+func (r *matchRepository) Delete(id, version int) error {
+	query := `
+		UPDATE matches
+		SET deleted_at = $1, version = version + 1
+		WHERE id = $2 AND version = $3 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id, version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// The row was changed or deleted since it was read
+	if rowsAffected == 0 {
+		return ErrVersionConflict
 	}
 
 	return nil
@@ -322,8 +516,7 @@ func (r *matchRepository) FindCompletedMatches() ([]models.Match, error) {
 	var matches []models.Match
 	for rows.Next() {
 		var match models.Match
-		var homeTeam, awayTeam models.Team
-		var homeLogoURL, awayLogoURL sql.NullString
+		var homeTeam, awayTeam joinedTeam
 
 		err := rows.Scan(
 			&match.ID,
@@ -338,21 +531,19 @@ func (r *matchRepository) FindCompletedMatches() ([]models.Match, error) {
 			&match.UpdatedAt,
 			&homeTeam.ID,
 			&homeTeam.Name,
-			&homeLogoURL,
+			&homeTeam.LogoURL,
 			&homeTeam.HomeCity,
 			&awayTeam.ID,
 			&awayTeam.Name,
-			&awayLogoURL,
+			&awayTeam.LogoURL,
 			&awayTeam.HomeCity,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		homeTeam.LogoURL = homeLogoURL
-		awayTeam.LogoURL = awayLogoURL
-		match.HomeTeam = &homeTeam
-		match.AwayTeam = &awayTeam
+		match.HomeTeam = homeTeam.team()
+		match.AwayTeam = awayTeam.team()
 		matches = append(matches, match)
 	}
 
@@ -2,26 +2,29 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
+	"fmt"
 	"football-management-api/internal/models"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type PlayerRepository interface {
 	Create(player *models.Player) error
 	FindByID(id int) (*models.Player, error)
-	FindAll(limit, offset int) ([]models.Player, int64, error)
+	FindAll(filter models.PlayerFilter, opts models.ListOptions) ([]models.Player, models.PageInfo, error)
 	FindByTeamID(teamID int) ([]models.Player, error)
+	FindByTeamIDs(teamIDs []int) ([]models.Player, error)
 	Update(id int, player *models.Player) error
-	Delete(id int) error
+	Delete(id, version int) error
 	CheckJerseyNumberExists(teamID, jerseyNumber, excludePlayerID int) (bool, error)
 }
 
 type playerRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewPlayerRepository(db *sql.DB) PlayerRepository {
+func NewPlayerRepository(db DBTX) PlayerRepository {
 	return &playerRepository{db: db}
 }
 
@@ -54,7 +57,7 @@ func (r *playerRepository) Create(player *models.Player) error {
 // FindByID finds a player by ID
 func (r *playerRepository) FindByID(id int) (*models.Player, error) {
 	query := `
-		SELECT p.id, p.team_id, p.name, p.height, p.weight, p.position, p.jersey_number, 
+		SELECT p.id, p.team_id, p.name, p.height, p.weight, p.position, p.jersey_number, p.version,
 		       p.created_at, p.updated_at,
 		       t.id, t.name, t.logo_url, t.home_city
 		FROM players p
@@ -63,8 +66,7 @@ func (r *playerRepository) FindByID(id int) (*models.Player, error) {
 	`
 
 	var player models.Player
-	var team models.Team
-	var logoURL sql.NullString
+	var team joinedTeam
 
 	err := r.db.QueryRow(query, id).Scan(
 		&player.ID,
@@ -74,61 +76,102 @@ func (r *playerRepository) FindByID(id int) (*models.Player, error) {
 		&player.Weight,
 		&player.Position,
 		&player.JerseyNumber,
+		&player.Version,
 		&player.CreatedAt,
 		&player.UpdatedAt,
 		&team.ID,
 		&team.Name,
-		&logoURL,
+		&team.LogoURL,
 		&team.HomeCity,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, errors.New("pemain tidak ditemukan")
+		return nil, ErrPlayerNotFound
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	team.LogoURL = logoURL
-	player.Team = &team
+	player.Team = team.team()
 
 	return &player, nil
 }
 
-// FindAll finds all players with pagination
-func (r *playerRepository) FindAll(limit, offset int) ([]models.Player, int64, error) {
-	// Get total count
-	var total int64
-	countQuery := "SELECT COUNT(*) FROM players WHERE deleted_at IS NULL"
-	err := r.db.QueryRow(countQuery).Scan(&total)
-	if err != nil {
-		return nil, 0, err
+// playerTeamSortColumn is the sort column of the team name, which is NULL when the team was deleted
+const playerTeamSortColumn = "COALESCE(t.name, '')"
+
+// playerSortColumns maps the sort keys accepted by the player list to their columns
+var playerSortColumns = map[string]string{
+	"name":          "p.name",
+	"height":        "p.height",
+	"weight":        "p.weight",
+	"position":      "p.position",
+	"jersey_number": "p.jersey_number",
+	"team":          playerTeamSortColumn,
+	"created_at":    "p.created_at",
+}
+
+// playerDefaultSort orders the player list when no sort is requested
+var playerDefaultSort = []models.SortField{{Field: "created_at", Desc: true}}
+
+// FindAll finds players matching the filter with search, sorting and offset or keyset pagination
+func (r *playerRepository) FindAll(filter models.PlayerFilter, opts models.ListOptions) ([]models.Player, models.PageInfo, error) {
+	var page models.PageInfo
+	keys := resolveSort(opts.Sort, playerSortColumns, playerDefaultSort, "p.id")
+
+	qb := &queryBuilder{}
+	qb.where("p.deleted_at IS NULL")
+
+	if filter.TeamID > 0 {
+		qb.where("p.team_id = ?", filter.TeamID)
+	}
+	if filter.Position != "" {
+		qb.where("p.position = ?", filter.Position)
+	}
+	if filter.HeightMin > 0 {
+		qb.where("p.height >= ?", filter.HeightMin)
+	}
+	if filter.HeightMax > 0 {
+		qb.where("p.height <= ?", filter.HeightMax)
+	}
+	if opts.Search != "" {
+		qb.where("p.name ILIKE ?", searchPattern(opts.Search))
+	}
+
+	if opts.Cursor == nil {
+		// Get total count
+		countQuery := "SELECT COUNT(*) FROM players p " + qb.whereClause()
+		err := r.db.QueryRow(countQuery, qb.args...).Scan(&page.Total)
+		if err != nil {
+			return nil, page, err
+		}
+	} else if err := qb.keyset(keys, opts.Cursor); err != nil {
+		return nil, page, err
 	}
 
 	// Get players
-	query := `
+	query := fmt.Sprintf(`
 		SELECT p.id, p.team_id, p.name, p.height, p.weight, p.position, p.jersey_number,
 		       p.created_at, p.updated_at,
 		       t.id, t.name, t.logo_url, t.home_city
 		FROM players p
 		LEFT JOIN teams t ON p.team_id = t.id AND t.deleted_at IS NULL
-		WHERE p.deleted_at IS NULL
-		ORDER BY p.created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+		%s
+		%s
+		%s
+	`, qb.whereClause(), orderByClause(keys, isReversed(opts)), qb.pageClause(opts))
 
-	rows, err := r.db.Query(query, limit, offset)
+	rows, err := r.db.Query(query, qb.args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, page, err
 	}
 	defer rows.Close()
 
 	var players []models.Player
 	for rows.Next() {
 		var player models.Player
-		var team models.Team
-		var logoURL sql.NullString
+		var team joinedTeam
 
 		err := rows.Scan(
 			&player.ID,
@@ -142,19 +185,47 @@ func (r *playerRepository) FindAll(limit, offset int) ([]models.Player, int64, e
 			&player.UpdatedAt,
 			&team.ID,
 			&team.Name,
-			&logoURL,
+			&team.LogoURL,
 			&team.HomeCity,
 		)
 		if err != nil {
-			return nil, 0, err
+			return nil, page, err
 		}
 
-		team.LogoURL = logoURL
-		player.Team = &team
+		player.Team = team.team()
 		players = append(players, player)
 	}
 
-	return players, total, nil
+	if opts.Cursor != nil {
+		players, page = keysetPage(players, keys, opts, playerColumnValue)
+	}
+
+	return players, page, nil
+}
+
+// playerColumnValue returns a player's value for a sort column, used to build cursors
+func playerColumnValue(player *models.Player, column string) interface{} {
+	switch column {
+	case "p.name":
+		return player.Name
+	case "p.height":
+		return player.Height
+	case "p.weight":
+		return player.Weight
+	case "p.position":
+		return player.Position
+	case "p.jersey_number":
+		return player.JerseyNumber
+	case playerTeamSortColumn:
+		if player.Team == nil {
+			return ""
+		}
+		return player.Team.Name
+	case "p.created_at":
+		return player.CreatedAt
+	default:
+		return player.ID
+	}
 }
 
 // FindByTeamID finds all players by team ID
@@ -195,12 +266,51 @@ func (r *playerRepository) FindByTeamID(teamID int) ([]models.Player, error) {
 	return players, nil
 }
 
-// Update updates a player
+// FindByTeamIDs finds the players of the given teams with a single query
+func (r *playerRepository) FindByTeamIDs(teamIDs []int) ([]models.Player, error) {
+	query := `
+		SELECT id, team_id, name, height, weight, position, jersey_number, created_at, updated_at
+		FROM players
+		WHERE team_id = ANY($1) AND deleted_at IS NULL
+		ORDER BY team_id ASC, jersey_number ASC
+	`
+
+	rows, err := r.db.Query(query, pq.Array(teamIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []models.Player
+	for rows.Next() {
+		var player models.Player
+		err := rows.Scan(
+			&player.ID,
+			&player.TeamID,
+			&player.Name,
+			&player.Height,
+			&player.Weight,
+			&player.Position,
+			&player.JerseyNumber,
+			&player.CreatedAt,
+			&player.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, player)
+	}
+
+	return players, nil
+}
+
+// Update updates a player if it still has the version it was read with
 func (r *playerRepository) Update(id int, player *models.Player) error {
 	query := `
 		UPDATE players
-		SET team_id = $1, name = $2, height = $3, weight = $4, position = $5, jersey_number = $6, updated_at = $7
-		WHERE id = $8 AND deleted_at IS NULL
+		SET team_id = $1, name = $2, height = $3, weight = $4, position = $5, jersey_number = $6, updated_at = $7, version = version + 1,
+			team_joined_at = CASE WHEN team_id = $1 THEN team_joined_at ELSE CURRENT_DATE END
+		WHERE id = $8 AND version = $9 AND deleted_at IS NULL
 	`
 
 	result, err := r.db.Exec(query,
@@ -212,6 +322,7 @@ func (r *playerRepository) Update(id int, player *models.Player) error {
 		player.JerseyNumber,
 		time.Now(),
 		id,
+		player.Version,
 	)
 
 	if err != nil {
@@ -223,22 +334,23 @@ func (r *playerRepository) Update(id int, player *models.Player) error {
 		return err
 	}
 
+	// The row was changed or deleted since it was read
 	if rowsAffected == 0 {
-		return errors.New("pemain tidak ditemukan")
+		return ErrVersionConflict
 	}
 
 	return nil
 }
 
-// Delete soft deletes a player
-func (r *playerRepository) Delete(id int) error {
+// Delete soft deletes a player if it still has the given version
+func (r *playerRepository) Delete(id, version int) error {
 	query := `
 		UPDATE players
-		SET deleted_at = $1
-		WHERE id = $2 AND deleted_at IS NULL
+		SET deleted_at = $1, version = version + 1
+		WHERE id = $2 AND version = $3 AND deleted_at IS NULL
 	`
 
-	result, err := r.db.Exec(query, time.Now(), id)
+	result, err := r.db.Exec(query, time.Now(), id, version)
 	if err != nil {
 		return err
 	}
@@ -248,8 +360,9 @@ func (r *playerRepository) Delete(id int) error {
 		return err
 	}
 
+	// The row was changed or deleted since it was read
 	if rowsAffected == 0 {
-		return errors.New("pemain tidak ditemukan")
+		return ErrVersionConflict
 	}
 
 	return nil
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"fmt"
+	"football-management-api/internal/models"
+	"strings"
+)
+
+// queryBuilder collects WHERE conditions together with their positional arguments
+type queryBuilder struct {
+	conditions []string
+	args       []interface{}
+}
+
+// where adds a condition. Each "?" in the condition is replaced, in order, with a
+// positional parameter bound to the matching argument.
+func (b *queryBuilder) where(condition string, args ...interface{}) {
+	for _, arg := range args {
+		condition = strings.Replace(condition, "?", b.arg(arg), 1)
+	}
+	b.conditions = append(b.conditions, condition)
+}
+
+// arg binds an argument and returns its positional parameter
+func (b *queryBuilder) arg(value interface{}) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// whereClause returns the WHERE clause for all collected conditions
+func (b *queryBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// limitClause binds limit and offset and returns the LIMIT clause. A limit of 0 means no limit.
+func (b *queryBuilder) limitClause(limit, offset int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("LIMIT %s OFFSET %s", b.arg(limit), b.arg(offset))
+}
+
+// pageClause returns the LIMIT clause for the requested pagination mode. Keyset pages fetch
+// one extra row to detect whether another page follows.
+func (b *queryBuilder) pageClause(opts models.ListOptions) string {
+	if opts.Cursor != nil {
+		return b.limitClause(opts.Limit+1, 0)
+	}
+	return b.limitClause(opts.Limit, opts.Offset)
+}
+
+// keyset adds the condition selecting rows after the cursor, or before it for a prev cursor.
+// For keys (a, b, id) this expands to (a > $1) OR (a = $1 AND b > $2) OR (a = $1 AND b = $2 AND id > $3),
+// with each comparison flipped for descending keys. NULL never compares, so a sort column that can
+// be NULL, such as the name of a LEFT JOINed team, has to be wrapped in COALESCE in its sort
+// columns, or its rows would be skipped or repeated between pages.
+func (b *queryBuilder) keyset(keys []sortKey, cursor *models.Cursor) error {
+	if len(cursor.Values) == 0 {
+		return nil
+	}
+
+	if len(cursor.Values) != len(keys) {
+		return ErrInvalidCursor
+	}
+
+	var clauses []string
+	for i, key := range keys {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, keys[j].column+" = "+b.arg(cursor.Values[j]))
+		}
+
+		operator := ">"
+		if key.desc != cursor.Prev {
+			operator = "<"
+		}
+		parts = append(parts, key.column+" "+operator+" "+b.arg(cursor.Values[i]))
+
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	b.conditions = append(b.conditions, "("+strings.Join(clauses, " OR ")+")")
+	return nil
+}
+
+// sortKey is a single resolved ORDER BY column
+type sortKey struct {
+	column string
+	desc   bool
+}
+
+// resolveSort maps the requested sort fields to columns, falling back to the default sort.
+// Only fields present in columns are used, so client input never reaches the SQL text. The
+// tie breaker is always appended to keep the order deterministic between pages.
+func resolveSort(sort []models.SortField, columns map[string]string, defaultSort []models.SortField, tieBreaker string) []sortKey {
+	var keys []sortKey
+	appendKeys := func(fields []models.SortField) {
+		for _, field := range fields {
+			column, ok := columns[field.Field]
+			if !ok {
+				continue
+			}
+
+			// A sort key may map to several columns, e.g. match date and kick-off time
+			for _, col := range splitColumns(column) {
+				keys = append(keys, sortKey{column: col, desc: field.Desc})
+			}
+		}
+	}
+
+	appendKeys(sort)
+	if len(keys) == 0 {
+		appendKeys(defaultSort)
+	}
+
+	return append(keys, sortKey{column: tieBreaker, desc: true})
+}
+
+// splitColumns splits a comma separated list of sort columns. Commas inside parentheses belong
+// to an expression, such as the arguments of COALESCE, and do not split.
+func splitColumns(columns string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range columns {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(columns[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, strings.TrimSpace(columns[start:]))
+}
+
+// orderByClause builds the ORDER BY clause for the keys. Reverse flips every direction,
+// which is how keyset pages before a cursor are fetched.
+func orderByClause(keys []sortKey, reverse bool) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction := "ASC"
+		if key.desc != reverse {
+			direction = "DESC"
+		}
+		parts = append(parts, key.column+" "+direction)
+	}
+
+	return "ORDER BY " + strings.Join(parts, ", ")
+}
+
+// isReversed reports whether a page is fetched backwards from a prev cursor
+func isReversed(opts models.ListOptions) bool {
+	return opts.Cursor != nil && opts.Cursor.Prev
+}
+
+// keysetPage trims a keyset page fetched with one extra row, restores its order when it was
+// fetched backwards and builds the cursors around it. value returns an item's value for a column.
+func keysetPage[T any](items []T, keys []sortKey, opts models.ListOptions, value func(item *T, column string) interface{}) ([]T, models.PageInfo) {
+	var page models.PageInfo
+
+	hasMore := len(items) > opts.Limit
+	if hasMore {
+		items = items[:opts.Limit]
+	}
+
+	if opts.Cursor.Prev {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	if len(items) == 0 {
+		return items, page
+	}
+
+	cursorAt := func(item *T, prev bool) *models.Cursor {
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = value(item, key.column)
+		}
+		return &models.Cursor{Values: values, Prev: prev}
+	}
+
+	started := len(opts.Cursor.Values) > 0
+	if (hasMore && !opts.Cursor.Prev) || (started && opts.Cursor.Prev) {
+		page.NextCursor = cursorAt(&items[len(items)-1], false)
+	}
+	if (hasMore && opts.Cursor.Prev) || (started && !opts.Cursor.Prev) {
+		page.PrevCursor = cursorAt(&items[0], true)
+	}
+
+	return items, page
+}
+
+// searchPattern escapes LIKE wildcards in a search term and wraps it for a contains match
+func searchPattern(search string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(search) + "%"
+}
@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"football-management-api/internal/models"
+)
+
+func TestQueryBuilderWhere(t *testing.T) {
+	var b queryBuilder
+	b.where("deleted_at IS NULL")
+	b.where("home_city = ?", "Jakarta")
+	b.where("founded_year BETWEEN ? AND ?", 1900, 2000)
+
+	wantClause := "WHERE deleted_at IS NULL AND home_city = $1 AND founded_year BETWEEN $2 AND $3"
+	if got := b.whereClause(); got != wantClause {
+		t.Errorf("whereClause() = %q, want %q", got, wantClause)
+	}
+
+	wantArgs := []interface{}{"Jakarta", 1900, 2000}
+	if !reflect.DeepEqual(b.args, wantArgs) {
+		t.Errorf("args = %v, want %v", b.args, wantArgs)
+	}
+}
+
+func TestQueryBuilderEmptyWhereClause(t *testing.T) {
+	var b queryBuilder
+	if got := b.whereClause(); got != "" {
+		t.Errorf("whereClause() = %q, want empty", got)
+	}
+}
+
+func TestQueryBuilderLimitClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		offset   int
+		want     string
+		wantArgs []interface{}
+	}{
+		{"no limit", 0, 0, "", nil},
+		{"first page", 10, 0, "LIMIT $1 OFFSET $2", []interface{}{10, 0}},
+		{"later page", 10, 20, "LIMIT $1 OFFSET $2", []interface{}{10, 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b queryBuilder
+			if got := b.limitClause(tt.limit, tt.offset); got != tt.want {
+				t.Errorf("limitClause() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(b.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", b.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestResolveSort(t *testing.T) {
+	columns := map[string]string{
+		"name": "t.name",
+		"date": "m.match_date, m.match_time",
+	}
+	defaultSort := []models.SortField{{Field: "name"}}
+
+	tests := []struct {
+		name string
+		sort []models.SortField
+		want []sortKey
+	}{
+		{
+			name: "default sort",
+			want: []sortKey{{column: "t.name"}, {column: "t.id", desc: true}},
+		},
+		{
+			name: "descending field",
+			sort: []models.SortField{{Field: "name", Desc: true}},
+			want: []sortKey{{column: "t.name", desc: true}, {column: "t.id", desc: true}},
+		},
+		{
+			name: "field mapped to several columns",
+			sort: []models.SortField{{Field: "date", Desc: true}, {Field: "name"}},
+			want: []sortKey{
+				{column: "m.match_date", desc: true},
+				{column: "m.match_time", desc: true},
+				{column: "t.name"},
+				{column: "t.id", desc: true},
+			},
+		},
+		{
+			name: "unknown fields fall back to the default sort",
+			sort: []models.SortField{{Field: "t.name; DROP TABLE teams"}},
+			want: []sortKey{{column: "t.name"}, {column: "t.id", desc: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveSort(tt.sort, columns, defaultSort, "t.id")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveSort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderByClause(t *testing.T) {
+	keys := []sortKey{{column: "t.name"}, {column: "t.id", desc: true}}
+
+	tests := []struct {
+		name    string
+		reverse bool
+		want    string
+	}{
+		{"forward", false, "ORDER BY t.name ASC, t.id DESC"},
+		{"reversed", true, "ORDER BY t.name DESC, t.id ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderByClause(keys, tt.reverse); got != tt.want {
+				t.Errorf("orderByClause() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchPattern(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"persija", "%persija%"},
+		{"100%", `%100\%%`},
+		{"a_b", `%a\_b%`},
+		{`c:\`, `%c:\\%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			if got := searchPattern(tt.search); got != tt.want {
+				t.Errorf("searchPattern(%q) = %q, want %q", tt.search, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderKeyset(t *testing.T) {
+	keys := []sortKey{{column: "t.name"}, {column: "t.founded_year", desc: true}, {column: "t.id", desc: true}}
+
+	tests := []struct {
+		name     string
+		cursor   *models.Cursor
+		want     string
+		wantArgs []interface{}
+		wantErr  error
+	}{
+		{
+			name:   "first page adds no condition",
+			cursor: &models.Cursor{},
+		},
+		{
+			name:   "next page",
+			cursor: &models.Cursor{Values: []interface{}{"Persija", 1928, 5}},
+			want: "WHERE ((t.name > $1) OR (t.name = $2 AND t.founded_year < $3) " +
+				"OR (t.name = $4 AND t.founded_year = $5 AND t.id < $6))",
+			wantArgs: []interface{}{"Persija", "Persija", 1928, "Persija", 1928, 5},
+		},
+		{
+			name:   "prev page flips every comparison",
+			cursor: &models.Cursor{Values: []interface{}{"Persija", 1928, 5}, Prev: true},
+			want: "WHERE ((t.name < $1) OR (t.name = $2 AND t.founded_year > $3) " +
+				"OR (t.name = $4 AND t.founded_year = $5 AND t.id > $6))",
+			wantArgs: []interface{}{"Persija", "Persija", 1928, "Persija", 1928, 5},
+		},
+		{
+			name:    "cursor of another sort",
+			cursor:  &models.Cursor{Values: []interface{}{"Persija", 5}},
+			wantErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b queryBuilder
+			if err := b.keyset(keys, tt.cursor); err != tt.wantErr {
+				t.Fatalf("keyset() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := b.whereClause(); got != tt.want {
+				t.Errorf("whereClause() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(b.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", b.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderPageClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     models.ListOptions
+		wantArgs []interface{}
+	}{
+		{"offset page", models.ListOptions{Limit: 10, Offset: 20}, []interface{}{10, 20}},
+		{"keyset page fetches one extra row", models.ListOptions{Limit: 10, Offset: 20, Cursor: &models.Cursor{}}, []interface{}{11, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b queryBuilder
+			b.pageClause(tt.opts)
+			if !reflect.DeepEqual(b.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", b.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSplitColumns(t *testing.T) {
+	tests := []struct {
+		columns string
+		want    []string
+	}{
+		{"t.name", []string{"t.name"}},
+		{"m.match_date, m.match_time", []string{"m.match_date", "m.match_time"}},
+		{"COALESCE(ht.name, '')", []string{"COALESCE(ht.name, '')"}},
+		{"g.minute, COALESCE(NULLIF(a, b), c), g.id", []string{"g.minute", "COALESCE(NULLIF(a, b), c)", "g.id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.columns, func(t *testing.T) {
+			if got := splitColumns(tt.columns); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitColumns(%q) = %q, want %q", tt.columns, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	type item struct{ id int }
+	keys := []sortKey{{column: "id"}}
+	value := func(it *item, column string) interface{} { return it.id }
+	items := func(ids ...int) []item {
+		var list []item
+		for _, id := range ids {
+			list = append(list, item{id: id})
+		}
+		return list
+	}
+	at := func(id int, prev bool) *models.Cursor {
+		return &models.Cursor{Values: []interface{}{id}, Prev: prev}
+	}
+
+	tests := []struct {
+		name     string
+		fetched  []item
+		cursor   *models.Cursor
+		wantIDs  []int
+		wantNext *models.Cursor
+		wantPrev *models.Cursor
+	}{
+		{
+			name:     "first page with more rows",
+			fetched:  items(1, 2, 3),
+			cursor:   &models.Cursor{},
+			wantIDs:  []int{1, 2},
+			wantNext: at(2, false),
+		},
+		{
+			name:    "only page",
+			fetched: items(1, 2),
+			cursor:  &models.Cursor{},
+			wantIDs: []int{1, 2},
+		},
+		{
+			name:     "middle page",
+			fetched:  items(3, 4, 5),
+			cursor:   at(2, false),
+			wantIDs:  []int{3, 4},
+			wantNext: at(4, false),
+			wantPrev: at(3, true),
+		},
+		{
+			name:     "last page",
+			fetched:  items(5),
+			cursor:   at(4, false),
+			wantIDs:  []int{5},
+			wantPrev: at(5, true),
+		},
+		{
+			name:     "prev page fetched backwards",
+			fetched:  items(4, 3, 2),
+			cursor:   at(5, true),
+			wantIDs:  []int{3, 4},
+			wantNext: at(4, false),
+			wantPrev: at(3, true),
+		},
+		{
+			name:     "prev page reaching the start",
+			fetched:  items(2, 1),
+			cursor:   at(3, true),
+			wantIDs:  []int{1, 2},
+			wantNext: at(2, false),
+		},
+		{
+			name:    "empty page",
+			cursor:  at(9, false),
+			wantIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := models.ListOptions{Limit: 2, Cursor: tt.cursor}
+			page, info := keysetPage(tt.fetched, keys, opts, value)
+
+			var ids []int
+			for _, it := range page {
+				ids = append(ids, it.id)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("page = %v, want %v", ids, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(info.NextCursor, tt.wantNext) {
+				t.Errorf("NextCursor = %+v, want %+v", info.NextCursor, tt.wantNext)
+			}
+			if !reflect.DeepEqual(info.PrevCursor, tt.wantPrev) {
+				t.Errorf("PrevCursor = %+v, want %+v", info.PrevCursor, tt.wantPrev)
+			}
+		})
+	}
+}
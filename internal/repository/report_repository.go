@@ -1,32 +1,52 @@
 package repository
 
 import (
-	"database/sql"
 	"football-management-api/internal/models"
+
+	"github.com/lib/pq"
 )
 
 type ReportRepository interface {
 	GetMatchReport(matchID int) (*models.MatchReport, error)
 	GetTeamWins(teamID int, upToMatchID int) (int, error)
 	GetTeamStatistics(teamID int) (*models.TeamStatistics, error)
+	GetLeagueHomeAdvantage() (*models.HomeAdvantage, error)
 	GetPlayerStatistics(playerID int) (*models.PlayerStatistics, error)
 	GetTopScorers(limit int) ([]models.PlayerStatistics, error)
+	GetHeadToHeadTopScorers(teamAID, teamBID, limit int) ([]models.TopScorerInfo, error)
+	GetPlayerDefensiveStatistics(playerID int) (*models.DefensiveStatistics, error)
+	GetCleanSheetLeaders(positions []string, limit int) ([]models.CleanSheetStatistics, error)
+	GetDataVersion() (*models.DataVersion, error)
 }
 
 type reportRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewReportRepository(db *sql.DB) ReportRepository {
+func NewReportRepository(db DBTX) ReportRepository {
 	return &reportRepository{db: db}
 }
 
+// teamInfo returns the report information of a joined team. A team that no longer exists is
+// reported by its ID alone.
+func teamInfo(id int, team *models.Team) models.TeamInfo {
+	if team == nil {
+		return models.TeamInfo{ID: id}
+	}
+	return models.TeamInfo{
+		ID:       team.ID,
+		Name:     team.Name,
+		LogoURL:  team.LogoURL.String,
+		HomeCity: team.HomeCity,
+	}
+}
+
 // GetMatchReport gets detailed match report
 func (r *reportRepository) GetMatchReport(matchID int) (*models.MatchReport, error) {
 	query := `
-		SELECT m.id, m.match_date, m.match_time,
-		       ht.id, ht.name, COALESCE(ht.logo_url, ''), ht.home_city,
-		       at.id, at.name, COALESCE(at.logo_url, ''), at.home_city,
+		SELECT m.id, m.match_date, m.match_time, m.home_team_id, m.away_team_id,
+		       ht.id, ht.name, ht.logo_url, ht.home_city,
+		       at.id, at.name, at.logo_url, at.home_city,
 		       COALESCE(m.home_score, 0), COALESCE(m.away_score, 0)
 		FROM matches m
 		LEFT JOIN teams ht ON m.home_team_id = ht.id
@@ -35,20 +55,24 @@ func (r *reportRepository) GetMatchReport(matchID int) (*models.MatchReport, err
 	`
 
 	var report models.MatchReport
+	var homeTeamID, awayTeamID int
+	var homeTeam, awayTeam joinedTeam
 	var homeScore, awayScore int
 
 	err := r.db.QueryRow(query, matchID).Scan(
 		&report.MatchID,
 		&report.MatchDate,
 		&report.MatchTime,
-		&report.HomeTeam.ID,
-		&report.HomeTeam.Name,
-		&report.HomeTeam.LogoURL,
-		&report.HomeTeam.HomeCity,
-		&report.AwayTeam.ID,
-		&report.AwayTeam.Name,
-		&report.AwayTeam.LogoURL,
-		&report.AwayTeam.HomeCity,
+		&homeTeamID,
+		&awayTeamID,
+		&homeTeam.ID,
+		&homeTeam.Name,
+		&homeTeam.LogoURL,
+		&homeTeam.HomeCity,
+		&awayTeam.ID,
+		&awayTeam.Name,
+		&awayTeam.LogoURL,
+		&awayTeam.HomeCity,
 		&homeScore,
 		&awayScore,
 	)
@@ -57,6 +81,9 @@ func (r *reportRepository) GetMatchReport(matchID int) (*models.MatchReport, err
 		return nil, err
 	}
 
+	report.HomeTeam = teamInfo(homeTeamID, homeTeam.team())
+	report.AwayTeam = teamInfo(awayTeamID, awayTeam.team())
+
 	report.FinalScore = models.ScoreInfo{
 		Home: homeScore,
 		Away: awayScore,
@@ -76,9 +103,9 @@ func (r *reportRepository) GetMatchReport(matchID int) (*models.MatchReport, err
 		SELECT g.id, g.player_id, p.name, t.name, g.goal_time
 		FROM goals g
 		JOIN players p ON g.player_id = p.id
-		JOIN teams t ON p.team_id = t.id
+		JOIN teams t ON g.team_id = t.id
 		WHERE g.match_id = $1 AND g.deleted_at IS NULL
-		ORDER BY g.goal_time ASC
+		ORDER BY g.minute ASC, g.stoppage_minute ASC, g.id ASC
 	`
 
 	rows, err := r.db.Query(goalsQuery, matchID)
@@ -109,7 +136,7 @@ func (r *reportRepository) GetMatchReport(matchID int) (*models.MatchReport, err
 		SELECT p.id, p.name, t.name, COUNT(*) as goals_count
 		FROM goals g
 		JOIN players p ON g.player_id = p.id
-		JOIN teams t ON p.team_id = t.id
+		JOIN teams t ON g.team_id = t.id
 		WHERE g.match_id = $1 AND g.deleted_at IS NULL
 		GROUP BY p.id, p.name, t.name
 		ORDER BY goals_count DESC
@@ -160,7 +187,7 @@ func (r *reportRepository) GetTeamWins(teamID int, upToMatchID int) (int, error)
 	return wins, nil
 }
 
-// GetTeamStatistics gets team statistics
+// GetTeamStatistics gets team statistics split into home and away records
 func (r *reportRepository) GetTeamStatistics(teamID int) (*models.TeamStatistics, error) {
 	var stats models.TeamStatistics
 	stats.TeamID = teamID
@@ -172,45 +199,40 @@ func (r *reportRepository) GetTeamStatistics(teamID int) (*models.TeamStatistics
 		return nil, err
 	}
 
-	// Get match statistics
+	// Get match statistics per venue
 	statsQuery := `
-		SELECT 
-			COUNT(*) as total_matches,
-			SUM(CASE 
-				WHEN (home_team_id = $1 AND home_score > away_score) OR (away_team_id = $2 AND away_score > home_score)
-				THEN 1 ELSE 0 
-			END) as wins,
-			SUM(CASE 
-				WHEN home_score = away_score 
-				THEN 1 ELSE 0 
-			END) as draws,
-			SUM(CASE 
-				WHEN (home_team_id = $3 AND home_score < away_score) OR (away_team_id = $4 AND away_score < home_score)
-				THEN 1 ELSE 0 
-			END) as losses,
-			COALESCE(SUM(CASE 
-				WHEN home_team_id = $5 THEN home_score 
-				WHEN away_team_id = $6 THEN away_score 
-			END), 0) as goals_scored,
-			COALESCE(SUM(CASE 
-				WHEN home_team_id = $7 THEN away_score 
-				WHEN away_team_id = $8 THEN home_score 
-			END), 0) as goals_conceded
+		SELECT
+			COUNT(*) FILTER (WHERE home_team_id = $1) as home_played,
+			COUNT(*) FILTER (WHERE home_team_id = $1 AND home_score > away_score) as home_wins,
+			COUNT(*) FILTER (WHERE home_team_id = $1 AND home_score = away_score) as home_draws,
+			COUNT(*) FILTER (WHERE home_team_id = $1 AND home_score < away_score) as home_losses,
+			COALESCE(SUM(home_score) FILTER (WHERE home_team_id = $1), 0) as home_goals_scored,
+			COALESCE(SUM(away_score) FILTER (WHERE home_team_id = $1), 0) as home_goals_conceded,
+			COUNT(*) FILTER (WHERE away_team_id = $1) as away_played,
+			COUNT(*) FILTER (WHERE away_team_id = $1 AND away_score > home_score) as away_wins,
+			COUNT(*) FILTER (WHERE away_team_id = $1 AND away_score = home_score) as away_draws,
+			COUNT(*) FILTER (WHERE away_team_id = $1 AND away_score < home_score) as away_losses,
+			COALESCE(SUM(away_score) FILTER (WHERE away_team_id = $1), 0) as away_goals_scored,
+			COALESCE(SUM(home_score) FILTER (WHERE away_team_id = $1), 0) as away_goals_conceded
 		FROM matches
-		WHERE (home_team_id = $9 OR away_team_id = $10)
+		WHERE (home_team_id = $1 OR away_team_id = $1)
 		AND status = 'Completed'
 		AND deleted_at IS NULL
 	`
 
-	err = r.db.QueryRow(statsQuery,
-		teamID, teamID, teamID, teamID, teamID, teamID, teamID, teamID, teamID, teamID,
-	).Scan(
-		&stats.TotalMatches,
-		&stats.TotalWins,
-		&stats.TotalDraws,
-		&stats.TotalLosses,
-		&stats.GoalsScored,
-		&stats.GoalsConceded,
+	err = r.db.QueryRow(statsQuery, teamID).Scan(
+		&stats.Home.Played,
+		&stats.Home.Wins,
+		&stats.Home.Draws,
+		&stats.Home.Losses,
+		&stats.Home.GoalsScored,
+		&stats.Home.GoalsConceded,
+		&stats.Away.Played,
+		&stats.Away.Wins,
+		&stats.Away.Draws,
+		&stats.Away.Losses,
+		&stats.Away.GoalsScored,
+		&stats.Away.GoalsConceded,
 	)
 
 	if err != nil {
@@ -220,6 +242,40 @@ func (r *reportRepository) GetTeamStatistics(teamID int) (*models.TeamStatistics
 	return &stats, nil
 }
 
+// GetLeagueHomeAdvantage gets home and away results across all completed matches
+func (r *reportRepository) GetLeagueHomeAdvantage() (*models.HomeAdvantage, error) {
+	query := `
+		SELECT
+			COUNT(*) as total_matches,
+			COUNT(*) FILTER (WHERE home_score > away_score) as home_wins,
+			COUNT(*) FILTER (WHERE away_score > home_score) as away_wins,
+			COUNT(*) FILTER (WHERE home_score = away_score) as draws,
+			COALESCE(SUM(home_score), 0) as home_goals,
+			COALESCE(SUM(away_score), 0) as away_goals
+		FROM matches
+		WHERE status = 'Completed'
+		AND home_score IS NOT NULL
+		AND away_score IS NOT NULL
+		AND deleted_at IS NULL
+	`
+
+	var advantage models.HomeAdvantage
+	err := r.db.QueryRow(query).Scan(
+		&advantage.TotalMatches,
+		&advantage.HomeWins,
+		&advantage.AwayWins,
+		&advantage.Draws,
+		&advantage.HomeGoals,
+		&advantage.AwayGoals,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &advantage, nil
+}
+
 // GetPlayerStatistics gets player goal statistics
 func (r *reportRepository) GetPlayerStatistics(playerID int) (*models.PlayerStatistics, error) {
 	query := `
@@ -285,3 +341,153 @@ func (r *reportRepository) GetTopScorers(limit int) ([]models.PlayerStatistics,
 
 	return scorers, nil
 }
+
+// GetHeadToHeadTopScorers gets the top scorers in completed matches between two teams
+func (r *reportRepository) GetHeadToHeadTopScorers(teamAID, teamBID, limit int) ([]models.TopScorerInfo, error) {
+	query := `
+		SELECT p.id, p.name, t.name, COUNT(*) as goals_count
+		FROM goals g
+		JOIN matches m ON g.match_id = m.id
+		JOIN players p ON g.player_id = p.id
+		JOIN teams t ON g.team_id = t.id
+		WHERE g.deleted_at IS NULL
+		AND m.deleted_at IS NULL
+		AND m.status = 'Completed'
+		AND (
+			(m.home_team_id = $1 AND m.away_team_id = $2)
+			OR
+			(m.home_team_id = $2 AND m.away_team_id = $1)
+		)
+		GROUP BY p.id, p.name, t.name
+		ORDER BY goals_count DESC, p.name ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, teamAID, teamBID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scorers []models.TopScorerInfo
+	for rows.Next() {
+		var scorer models.TopScorerInfo
+		err := rows.Scan(
+			&scorer.PlayerID,
+			&scorer.PlayerName,
+			&scorer.TeamName,
+			&scorer.GoalsScored,
+		)
+		if err != nil {
+			return nil, err
+		}
+		scorers = append(scorers, scorer)
+	}
+
+	return scorers, nil
+}
+
+// GetPlayerDefensiveStatistics gets clean sheets and goals conceded over the completed matches of the player's team
+// since the player joined it. Matches the team played before a transfer are not counted.
+func (r *reportRepository) GetPlayerDefensiveStatistics(playerID int) (*models.DefensiveStatistics, error) {
+	query := `
+		SELECT
+			COUNT(m.id) as matches_played,
+			COUNT(m.id) FILTER (WHERE
+				(m.home_team_id = p.team_id AND m.away_score = 0)
+				OR (m.away_team_id = p.team_id AND m.home_score = 0)
+			) as clean_sheets,
+			COALESCE(SUM(CASE
+				WHEN m.home_team_id = p.team_id THEN m.away_score
+				ELSE m.home_score
+			END), 0) as goals_conceded
+		FROM players p
+		LEFT JOIN matches m ON (m.home_team_id = p.team_id OR m.away_team_id = p.team_id)
+			AND m.status = 'Completed'
+			AND m.deleted_at IS NULL
+			AND (p.team_joined_at IS NULL OR m.match_date >= p.team_joined_at)
+		WHERE p.id = $1 AND p.deleted_at IS NULL
+	`
+
+	var stats models.DefensiveStatistics
+	err := r.db.QueryRow(query, playerID).Scan(
+		&stats.MatchesPlayed,
+		&stats.CleanSheets,
+		&stats.GoalsConceded,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetCleanSheetLeaders gets players in the given positions ordered by clean sheets, counted over the
+// matches of their team since they joined it
+func (r *reportRepository) GetCleanSheetLeaders(positions []string, limit int) ([]models.CleanSheetStatistics, error) {
+	query := `
+		SELECT p.id, p.name, t.name, p.position,
+			COUNT(m.id) as matches_played,
+			COUNT(m.id) FILTER (WHERE
+				(m.home_team_id = p.team_id AND m.away_score = 0)
+				OR (m.away_team_id = p.team_id AND m.home_score = 0)
+			) as clean_sheets,
+			COALESCE(SUM(CASE
+				WHEN m.home_team_id = p.team_id THEN m.away_score
+				ELSE m.home_score
+			END), 0) as goals_conceded
+		FROM players p
+		JOIN teams t ON p.team_id = t.id AND t.deleted_at IS NULL
+		JOIN matches m ON (m.home_team_id = p.team_id OR m.away_team_id = p.team_id)
+			AND m.status = 'Completed'
+			AND m.deleted_at IS NULL
+			AND (p.team_joined_at IS NULL OR m.match_date >= p.team_joined_at)
+		WHERE p.deleted_at IS NULL AND p.position::text = ANY($1)
+		GROUP BY p.id, p.name, t.name, p.position
+		ORDER BY clean_sheets DESC, goals_conceded ASC, p.name ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, pq.Array(positions), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leaders []models.CleanSheetStatistics
+	for rows.Next() {
+		var stats models.CleanSheetStatistics
+		err := rows.Scan(
+			&stats.PlayerID,
+			&stats.PlayerName,
+			&stats.TeamName,
+			&stats.Position,
+			&stats.MatchesPlayed,
+			&stats.CleanSheets,
+			&stats.GoalsConceded,
+		)
+		if err != nil {
+			return nil, err
+		}
+		leaders = append(leaders, stats)
+	}
+
+	return leaders, nil
+}
+
+// GetDataVersion gets the marker of the latest change to any data a report is built from. The
+// marker is moved by every unit of work that changed teams, players, matches or goals, including
+// purges, and never moves backwards.
+func (r *reportRepository) GetDataVersion() (*models.DataVersion, error) {
+	var dataVersion models.DataVersion
+	err := r.db.QueryRow(`SELECT version, changed_at FROM data_version WHERE id = 1`).Scan(
+		&dataVersion.Version,
+		&dataVersion.ChangedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dataVersion, nil
+}
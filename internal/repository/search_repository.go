@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"football-management-api/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type SearchRepository interface {
+	Search(term string, types []string, limit int) ([]models.SearchResult, error)
+}
+
+type searchRepository struct {
+	db DBTX
+}
+
+func NewSearchRepository(db DBTX) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// Search finds teams, players and matches whose names resemble the term, ranked by relevance.
+// Names are compared with search_normalize (lowercase, accents removed) so "jose" matches "José",
+// trigram similarity tolerates typos and full-text matching catches whole words in any order.
+func (r *searchRepository) Search(term string, types []string, limit int) ([]models.SearchResult, error) {
+	query := `
+		WITH q AS (
+			SELECT search_normalize($1) AS term, plainto_tsquery('simple', search_normalize($1)) AS tsq
+		)
+		SELECT type, id, title, subtitle, score
+		FROM (
+			SELECT 'team' AS type, t.id, t.name AS title, t.home_city AS subtitle,
+			       GREATEST(
+			           similarity(search_normalize(t.name), q.term),
+			           word_similarity(q.term, search_normalize(t.name)),
+			           word_similarity(q.term, search_normalize(t.home_city)) * 0.8,
+			           ts_rank(to_tsvector('simple', search_normalize(t.name || ' ' || t.home_city)), q.tsq)
+			       ) AS score
+			FROM teams t, q
+			WHERE 'team' = ANY($2) AND t.deleted_at IS NULL
+			  AND (search_normalize(t.name) % q.term
+			       OR q.term <% search_normalize(t.name)
+			       OR q.term <% search_normalize(t.home_city)
+			       OR to_tsvector('simple', search_normalize(t.name || ' ' || t.home_city)) @@ q.tsq)
+
+			UNION ALL
+
+			SELECT 'player' AS type, p.id, p.name AS title, COALESCE(tm.name, '') AS subtitle,
+			       GREATEST(
+			           similarity(search_normalize(p.name), q.term),
+			           word_similarity(q.term, search_normalize(p.name)),
+			           ts_rank(to_tsvector('simple', search_normalize(p.name)), q.tsq)
+			       ) AS score
+			FROM players p
+			CROSS JOIN q
+			LEFT JOIN teams tm ON p.team_id = tm.id AND tm.deleted_at IS NULL
+			WHERE 'player' = ANY($2) AND p.deleted_at IS NULL
+			  AND (search_normalize(p.name) % q.term
+			       OR q.term <% search_normalize(p.name)
+			       OR to_tsvector('simple', search_normalize(p.name)) @@ q.tsq)
+
+			UNION ALL
+
+			SELECT 'match' AS type, m.id, ht.name || ' vs ' || at.name AS title,
+			       to_char(m.match_date, 'YYYY-MM-DD') || ' ' || m.status AS subtitle,
+			       GREATEST(
+			           word_similarity(q.term, search_normalize(ht.name)),
+			           word_similarity(q.term, search_normalize(at.name))
+			       ) * 0.9 AS score
+			FROM matches m
+			CROSS JOIN q
+			JOIN teams ht ON m.home_team_id = ht.id AND ht.deleted_at IS NULL
+			JOIN teams at ON m.away_team_id = at.id AND at.deleted_at IS NULL
+			WHERE 'match' = ANY($2) AND m.deleted_at IS NULL
+			  AND (q.term <% search_normalize(ht.name)
+			       OR q.term <% search_normalize(at.name))
+		) results
+		ORDER BY score DESC, title ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, term, pq.Array(types), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		var result models.SearchResult
+		err := rows.Scan(
+			&result.Type,
+			&result.ID,
+			&result.Title,
+			&result.Subtitle,
+			&result.Score,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -2,25 +2,29 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
+	"fmt"
 	"football-management-api/internal/models"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type TeamRepository interface {
 	Create(team *models.Team) error
 	FindByID(id int) (*models.Team, error)
-	FindAll(limit, offset int) ([]models.Team, int64, error)
+	FindByIDs(ids []int) ([]models.Team, error)
+	FindAll(filter models.TeamFilter, opts models.ListOptions) ([]models.Team, models.PageInfo, error)
 	Update(id int, team *models.Team) error
-	Delete(id int) error
+	Delete(id, version int) error
+	Archive(id, version int) error
 	FindByName(name string) (*models.Team, error)
 }
 
 type teamRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewTeamRepository(db *sql.DB) TeamRepository {
+func NewTeamRepository(db DBTX) TeamRepository {
 	return &teamRepository{db: db}
 }
 
@@ -29,7 +33,7 @@ func (r *teamRepository) Create(team *models.Team) error {
 	query := `
 		INSERT INTO teams (name, logo_url, founded_year, home_address, home_city, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id
+		RETURNING id, version
 	`
 
 	err := r.db.QueryRow(query,
@@ -40,7 +44,7 @@ func (r *teamRepository) Create(team *models.Team) error {
 		team.HomeCity,
 		time.Now(),
 		time.Now(),
-	).Scan(&team.ID)
+	).Scan(&team.ID, &team.Version)
 
 	if err != nil {
 		return err
@@ -52,7 +56,7 @@ func (r *teamRepository) Create(team *models.Team) error {
 // FindByID finds a team by ID
 func (r *teamRepository) FindByID(id int) (*models.Team, error) {
 	query := `
-		SELECT id, name, logo_url, founded_year, home_address, home_city, created_at, updated_at
+		SELECT id, name, logo_url, founded_year, home_address, home_city, version, archived_at, created_at, updated_at
 		FROM teams
 		WHERE id = $1 AND deleted_at IS NULL
 	`
@@ -65,12 +69,14 @@ func (r *teamRepository) FindByID(id int) (*models.Team, error) {
 		&team.FoundedYear,
 		&team.HomeAddress,
 		&team.HomeCity,
+		&team.Version,
+		&team.ArchivedAt,
 		&team.CreatedAt,
 		&team.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, errors.New("tim tidak ditemukan")
+		return nil, ErrTeamNotFound
 	}
 
 	if err != nil {
@@ -80,28 +86,106 @@ func (r *teamRepository) FindByID(id int) (*models.Team, error) {
 	return &team, nil
 }
 
-// FindAll finds all teams with pagination
-func (r *teamRepository) FindAll(limit, offset int) ([]models.Team, int64, error) {
-	// Get total count
-	var total int64
-	countQuery := "SELECT COUNT(*) FROM teams WHERE deleted_at IS NULL"
-	err := r.db.QueryRow(countQuery).Scan(&total)
+// FindByIDs finds the teams with the given IDs with a single query
+func (r *teamRepository) FindByIDs(ids []int) ([]models.Team, error) {
+	query := `
+		SELECT id, name, logo_url, founded_year, home_address, home_city, archived_at, created_at, updated_at
+		FROM teams
+		WHERE id = ANY($1) AND deleted_at IS NULL
+	`
+
+	rows, err := r.db.Query(query, pq.Array(ids))
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []models.Team
+	for rows.Next() {
+		var team models.Team
+		err := rows.Scan(
+			&team.ID,
+			&team.Name,
+			&team.LogoURL,
+			&team.FoundedYear,
+			&team.HomeAddress,
+			&team.HomeCity,
+			&team.ArchivedAt,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+// teamSortColumns maps the sort keys accepted by the team list to their columns
+var teamSortColumns = map[string]string{
+	"name":         "name",
+	"city":         "home_city",
+	"founded_year": "founded_year",
+	"created_at":   "created_at",
+}
+
+// teamDefaultSort orders the team list when no sort is requested
+var teamDefaultSort = []models.SortField{{Field: "created_at", Desc: true}}
+
+// FindAll finds teams matching the filter with search, sorting and offset or keyset pagination
+func (r *teamRepository) FindAll(filter models.TeamFilter, opts models.ListOptions) ([]models.Team, models.PageInfo, error) {
+	var page models.PageInfo
+	keys := resolveSort(opts.Sort, teamSortColumns, teamDefaultSort, "id")
+
+	qb := &queryBuilder{}
+	qb.where("deleted_at IS NULL")
+
+	// The city filter matches the whole name, ignoring case, so LIKE wildcards in it are literal
+	if filter.City != "" {
+		qb.where("LOWER(home_city) = LOWER(?)", filter.City)
+	}
+	if filter.FoundedFrom > 0 {
+		qb.where("founded_year >= ?", filter.FoundedFrom)
+	}
+	if filter.FoundedTo > 0 {
+		qb.where("founded_year <= ?", filter.FoundedTo)
+	}
+	if filter.Archived != nil {
+		if *filter.Archived {
+			qb.where("archived_at IS NOT NULL")
+		} else {
+			qb.where("archived_at IS NULL")
+		}
+	}
+	if opts.Search != "" {
+		qb.where("name ILIKE ?", searchPattern(opts.Search))
+	}
+
+	if opts.Cursor == nil {
+		// Get total count
+		countQuery := "SELECT COUNT(*) FROM teams " + qb.whereClause()
+		err := r.db.QueryRow(countQuery, qb.args...).Scan(&page.Total)
+		if err != nil {
+			return nil, page, err
+		}
+	} else if err := qb.keyset(keys, opts.Cursor); err != nil {
+		return nil, page, err
 	}
 
 	// Get teams
-	query := `
-		SELECT id, name, logo_url, founded_year, home_address, home_city, created_at, updated_at
+	query := fmt.Sprintf(`
+		SELECT id, name, logo_url, founded_year, home_address, home_city, archived_at, created_at, updated_at
 		FROM teams
-		WHERE deleted_at IS NULL
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
+		%s
+		%s
+		%s
+	`, qb.whereClause(), orderByClause(keys, isReversed(opts)), qb.pageClause(opts))
 
-	rows, err := r.db.Query(query, limit, offset)
+	rows, err := r.db.Query(query, qb.args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, page, err
 	}
 	defer rows.Close()
 
@@ -115,24 +199,45 @@ func (r *teamRepository) FindAll(limit, offset int) ([]models.Team, int64, error
 			&team.FoundedYear,
 			&team.HomeAddress,
 			&team.HomeCity,
+			&team.ArchivedAt,
 			&team.CreatedAt,
 			&team.UpdatedAt,
 		)
 		if err != nil {
-			return nil, 0, err
+			return nil, page, err
 		}
 		teams = append(teams, team)
 	}
 
-	return teams, total, nil
+	if opts.Cursor != nil {
+		teams, page = keysetPage(teams, keys, opts, teamColumnValue)
+	}
+
+	return teams, page, nil
 }
 
-// Update updates a team
+// teamColumnValue returns a team's value for a sort column, used to build cursors
+func teamColumnValue(team *models.Team, column string) interface{} {
+	switch column {
+	case "name":
+		return team.Name
+	case "home_city":
+		return team.HomeCity
+	case "founded_year":
+		return team.FoundedYear
+	case "created_at":
+		return team.CreatedAt
+	default:
+		return team.ID
+	}
+}
+
+// Update updates a team if it still has the version it was read with
 func (r *teamRepository) Update(id int, team *models.Team) error {
 	query := `
 		UPDATE teams
-		SET name = $1, logo_url = $2, founded_year = $3, home_address = $4, home_city = $5, updated_at = $6
-		WHERE id = $7 AND deleted_at IS NULL
+		SET name = $1, logo_url = $2, founded_year = $3, home_address = $4, home_city = $5, updated_at = $6, version = version + 1
+		WHERE id = $7 AND version = $8 AND deleted_at IS NULL
 	`
 
 	result, err := r.db.Exec(query,
@@ -143,6 +248,7 @@ func (r *teamRepository) Update(id int, team *models.Team) error {
 		team.HomeCity,
 		time.Now(),
 		id,
+		team.Version,
 	)
 
 	if err != nil {
@@ -154,22 +260,23 @@ func (r *teamRepository) Update(id int, team *models.Team) error {
 		return err
 	}
 
+	// The row was changed or deleted since it was read
 	if rowsAffected == 0 {
-		return errors.New("tim tidak ditemukan")
+		return ErrVersionConflict
 	}
 
 	return nil
 }
 
-// Delete soft deletes a team
-func (r *teamRepository) Delete(id int) error {
+// Delete soft deletes a team if it still has the given version
+func (r *teamRepository) Delete(id, version int) error {
 	query := `
 		UPDATE teams
-		SET deleted_at = $1
-		WHERE id = $2 AND deleted_at IS NULL
+		SET deleted_at = $1, version = version + 1
+		WHERE id = $2 AND version = $3 AND deleted_at IS NULL
 	`
 
-	result, err := r.db.Exec(query, time.Now(), id)
+	result, err := r.db.Exec(query, time.Now(), id, version)
 	if err != nil {
 		return err
 	}
@@ -179,8 +286,36 @@ func (r *teamRepository) Delete(id int) error {
 		return err
 	}
 
+	// The row was changed or deleted since it was read
 	if rowsAffected == 0 {
-		return errors.New("tim tidak ditemukan")
+		return ErrVersionConflict
+	}
+
+	return nil
+}
+
+// Archive archives a team if it still has the given version. Archiving an archived team keeps
+// its original archive time.
+func (r *teamRepository) Archive(id, version int) error {
+	query := `
+		UPDATE teams
+		SET archived_at = COALESCE(archived_at, $1), updated_at = $1, version = version + 1
+		WHERE id = $2 AND version = $3 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id, version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	// The row was changed or deleted since it was read
+	if rowsAffected == 0 {
+		return ErrVersionConflict
 	}
 
 	return nil
@@ -189,7 +324,7 @@ func (r *teamRepository) Delete(id int) error {
 // FindByName finds a team by name
 func (r *teamRepository) FindByName(name string) (*models.Team, error) {
 	query := `
-		SELECT id, name, logo_url, founded_year, home_address, home_city, created_at, updated_at
+		SELECT id, name, logo_url, founded_year, home_address, home_city, archived_at, created_at, updated_at
 		FROM teams
 		WHERE name = $1 AND deleted_at IS NULL
 	`
@@ -202,6 +337,7 @@ func (r *teamRepository) FindByName(name string) (*models.Team, error) {
 		&team.FoundedYear,
 		&team.HomeAddress,
 		&team.HomeCity,
+		&team.ArchivedAt,
 		&team.CreatedAt,
 		&team.UpdatedAt,
 	)
@@ -216,3 +352,24 @@ func (r *teamRepository) FindByName(name string) (*models.Team, error) {
 
 	return &team, nil
 }
+
+// joinedTeam holds the columns of a LEFT JOINed team, which are NULL when the team was deleted
+type joinedTeam struct {
+	ID       sql.NullInt64
+	Name     sql.NullString
+	LogoURL  sql.NullString
+	HomeCity sql.NullString
+}
+
+// team returns the joined team, or nil when there is no active team
+func (t *joinedTeam) team() *models.Team {
+	if !t.ID.Valid {
+		return nil
+	}
+	return &models.Team{
+		ID:       int(t.ID.Int64),
+		Name:     t.Name.String,
+		LogoURL:  t.LogoURL,
+		HomeCity: t.HomeCity.String,
+	}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"database/sql"
+	"football-management-api/internal/models"
+	"time"
+)
+
+type TokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) error
+	FindRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error)
+	MarkRefreshTokenUsed(id int) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	IsRevoked(jti, familyID string) (bool, error)
+	DeleteExpired() error
+}
+
+type tokenRepository struct {
+	db DBTX
+}
+
+func NewTokenRepository(db DBTX) TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+// CreateRefreshToken stores a new refresh token
+func (r *tokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+
+	return r.db.QueryRow(query,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+		time.Now(),
+	).Scan(&token.ID)
+}
+
+// FindRefreshTokenByHash finds a refresh token by its hash, returning nil when there is none
+func (r *tokenRepository) FindRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, used_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var token models.RefreshToken
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed marks a refresh token as rotated. It returns false when the token was
+// already used, so two concurrent refreshes with the same token cannot both succeed.
+func (r *tokenRepository) MarkRefreshTokenUsed(id int) (bool, error) {
+	query := `
+		UPDATE refresh_tokens
+		SET used_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// RevokeFamily revokes every refresh token of a login session
+func (r *tokenRepository) RevokeFamily(familyID string) error {
+	query := `
+		UPDATE refresh_tokens
+		SET revoked_at = $1
+		WHERE family_id = $2 AND revoked_at IS NULL
+	`
+
+	_, err := r.db.Exec(query, time.Now(), familyID)
+	return err
+}
+
+// RevokeAccessToken adds an access token to the revocation list until it expires
+func (r *tokenRepository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, expires_at, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	_, err := r.db.Exec(query, jti, expiresAt, time.Now())
+	return err
+}
+
+// IsRevoked reports whether an access token was revoked, either by itself or because its
+// login session was revoked
+func (r *tokenRepository) IsRevoked(jti, familyID string) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1)
+		    OR EXISTS (SELECT 1 FROM refresh_tokens WHERE family_id = $2 AND revoked_at IS NOT NULL)
+	`
+
+	var revoked bool
+	if err := r.db.QueryRow(query, jti, familyID).Scan(&revoked); err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// DeleteExpired removes revocation entries and refresh tokens that have expired, since
+// expired tokens are rejected anyway
+func (r *tokenRepository) DeleteExpired() error {
+	now := time.Now()
+
+	if _, err := r.db.Exec(`DELETE FROM revoked_tokens WHERE expires_at < $1`, now); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(`DELETE FROM refresh_tokens WHERE expires_at < $1`, now)
+	return err
+}
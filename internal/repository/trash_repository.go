@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"database/sql"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
+	"football-management-api/internal/models"
+	"time"
+)
+
+// ErrInUse is returned when a deleted record cannot be purged because other records, active or
+// deleted, still reference it. Purging it would cascade to them without an audit entry.
+var ErrInUse = apperror.Conflict("IN_USE", "data masih dirujuk oleh data lain, termasuk data yang dihapus, sehingga tidak dapat dihapus permanen")
+
+// ErrInvalidTrashEntity is returned for an entity that has no trash
+var ErrInvalidTrashEntity = apperror.Validation("INVALID_TRASH_ENTITY", "entity trash tidak valid")
+
+type TrashRepository interface {
+	FindAll(entity string, limit, offset int) ([]models.TrashItem, int64, error)
+	FindByID(entity string, id int) (*models.TrashItem, error)
+	Restore(entity string, id int) error
+	Purge(entity string, id int) error
+	PurgeDeletedBefore(entity string, cutoff time.Time) ([]int, error)
+}
+
+type trashRepository struct {
+	db DBTX
+}
+
+func NewTrashRepository(db DBTX) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// trashTable describes how the deleted rows of a table are listed and when they may be purged
+type trashTable struct {
+	// from selects id, name, deleted_at, team_id, jersey_number, home_team_id, away_team_id,
+	// match_id and player_id, with the table itself unaliased
+	from string
+	// unreferenced holds when no row references the row, whether deleted or not, so purging it
+	// never cascades
+	unreferenced string
+}
+
+var trashTables = map[string]trashTable{
+	config.TrashTeams: {
+		from: `
+			SELECT teams.id, teams.name, teams.deleted_at, 0, 0, 0, 0, 0, 0
+			FROM teams`,
+		unreferenced: `
+			NOT EXISTS (SELECT 1 FROM players p WHERE p.team_id = teams.id)
+			AND NOT EXISTS (SELECT 1 FROM matches m WHERE m.home_team_id = teams.id OR m.away_team_id = teams.id)
+			AND NOT EXISTS (SELECT 1 FROM goals g WHERE g.team_id = teams.id)
+			AND NOT EXISTS (SELECT 1 FROM users u WHERE u.team_id = teams.id)`,
+	},
+	config.TrashPlayers: {
+		from: `
+			SELECT players.id, players.name, players.deleted_at, players.team_id, players.jersey_number, 0, 0, 0, 0
+			FROM players`,
+		unreferenced: `
+			NOT EXISTS (SELECT 1 FROM goals g WHERE g.player_id = players.id)`,
+	},
+	config.TrashMatches: {
+		from: `
+			SELECT matches.id, COALESCE(h.name, '') || ' vs ' || COALESCE(a.name, '') || ' (' || matches.match_date || ')',
+				matches.deleted_at, 0, 0, matches.home_team_id, matches.away_team_id, 0, 0
+			FROM matches
+			LEFT JOIN teams h ON h.id = matches.home_team_id
+			LEFT JOIN teams a ON a.id = matches.away_team_id`,
+		unreferenced: `
+			NOT EXISTS (SELECT 1 FROM goals g WHERE g.match_id = matches.id)`,
+	},
+	config.TrashGoals: {
+		from: `
+			SELECT goals.id, COALESCE(p.name, '') || ' ' || goals.goal_time || '''',
+				goals.deleted_at, 0, 0, 0, 0, goals.match_id, goals.player_id
+			FROM goals
+			LEFT JOIN players p ON p.id = goals.player_id`,
+		unreferenced: `TRUE`,
+	},
+}
+
+// table returns the trash description of an entity
+func (r *trashRepository) table(entity string) (trashTable, error) {
+	table, ok := trashTables[entity]
+	if !ok {
+		return trashTable{}, ErrInvalidTrashEntity
+	}
+	return table, nil
+}
+
+// FindAll finds the deleted rows of an entity, most recently deleted first
+func (r *trashRepository) FindAll(entity string, limit, offset int) ([]models.TrashItem, int64, error) {
+	table, err := r.table(entity)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM ` + entity + ` WHERE deleted_at IS NOT NULL`
+	if err := r.db.QueryRow(countQuery).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := table.from + ` WHERE ` + entity + `.deleted_at IS NOT NULL
+		ORDER BY ` + entity + `.deleted_at DESC, ` + entity + `.id DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	items := []models.TrashItem{}
+	for rows.Next() {
+		var item models.TrashItem
+		if err := scanTrashItem(rows, &item); err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+
+	return items, total, rows.Err()
+}
+
+// FindByID finds a deleted row of an entity
+func (r *trashRepository) FindByID(entity string, id int) (*models.TrashItem, error) {
+	table, err := r.table(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	query := table.from + ` WHERE ` + entity + `.id = $1 AND ` + entity + `.deleted_at IS NOT NULL`
+
+	var item models.TrashItem
+	err = scanTrashItem(r.db.QueryRow(query, id), &item)
+	if err == sql.ErrNoRows {
+		return nil, ErrTrashNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// Restore undoes the soft delete of a row
+func (r *trashRepository) Restore(entity string, id int) error {
+	if _, err := r.table(entity); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE ` + entity + `
+		SET deleted_at = NULL, version = version + 1, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NOT NULL
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrTrashNotFound
+	}
+
+	return nil
+}
+
+// Purge permanently deletes a deleted row. A row that other rows still reference is kept and
+// ErrInUse returned; its deleted children have to be purged first.
+func (r *trashRepository) Purge(entity string, id int) error {
+	table, err := r.table(entity)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM ` + entity + ` WHERE id = $1 AND deleted_at IS NOT NULL AND ` + table.unreferenced
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrInUse
+	}
+
+	return nil
+}
+
+// PurgeDeletedBefore permanently deletes the rows deleted before cutoff that no row references,
+// and returns their IDs
+func (r *trashRepository) PurgeDeletedBefore(entity string, cutoff time.Time) ([]int, error) {
+	table, err := r.table(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `DELETE FROM ` + entity + ` WHERE deleted_at < $1 AND ` + table.unreferenced + ` RETURNING id`
+
+	rows, err := r.db.Query(query, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// scanTrashItem scans a row selected by trashTable.from
+func scanTrashItem(row rowScanner, item *models.TrashItem) error {
+	return row.Scan(
+		&item.ID,
+		&item.Name,
+		&item.DeletedAt,
+		&item.TeamID,
+		&item.JerseyNumber,
+		&item.HomeTeamID,
+		&item.AwayTeamID,
+		&item.MatchID,
+		&item.PlayerID,
+	)
+}
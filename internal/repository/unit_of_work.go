@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// DBTX is the subset of *sql.DB and *sql.Tx used by repositories, so the same
+// repository can run against the connection pool or inside a transaction
+type DBTX interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Repositories groups repositories that share the same connection or transaction
+type Repositories struct {
+	Teams   TeamRepository
+	Players PlayerRepository
+	Matches MatchRepository
+	Goals   GoalRepository
+	Reports ReportRepository
+	Search  SearchRepository
+	Users   UserRepository
+	Tokens  TokenRepository
+	APIKeys APIKeyRepository
+	Audit   AuditRepository
+	Trash   TrashRepository
+}
+
+// NewRepositories creates all repositories bound to the given connection or transaction
+func NewRepositories(db DBTX) *Repositories {
+	return &Repositories{
+		Teams:   NewTeamRepository(db),
+		Players: NewPlayerRepository(db),
+		Matches: NewMatchRepository(db),
+		Goals:   NewGoalRepository(db),
+		Reports: NewReportRepository(db),
+		Search:  NewSearchRepository(db),
+		Users:   NewUserRepository(db),
+		Tokens:  NewTokenRepository(db),
+		APIKeys: NewAPIKeyRepository(db),
+		Audit:   NewAuditRepository(db),
+		Trash:   NewTrashRepository(db),
+	}
+}
+
+// UnitOfWork runs several repository operations atomically
+type UnitOfWork interface {
+	Do(fn func(repos *Repositories) error) error
+}
+
+// markUnitOfWork tells the data version triggers that the transaction bumps the data version
+// itself before it commits, instead of locking the data_version row on every write
+const markUnitOfWork = `SELECT set_config('app.unit_of_work', 'on', true)`
+
+// bumpDataVersion moves the data version forward when a trigger marked the transaction as
+// having changed report data. It runs right before commit, so the data_version row is always
+// the last lock a transaction takes and cannot be part of a deadlock.
+const bumpDataVersion = `
+	UPDATE data_version
+	SET version = version + 1, changed_at = GREATEST(changed_at, CURRENT_TIMESTAMP)
+	WHERE id = 1 AND current_setting('app.data_changed', true) = 'on'
+`
+
+type unitOfWork struct {
+	db *sql.DB
+}
+
+func NewUnitOfWork(db *sql.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn inside a transaction. The transaction is committed when fn returns
+// nil and rolled back when fn returns an error or panics. Unique violations,
+// deadlocks and serialization failures are returned as conflicts.
+func (u *unitOfWork) Do(fn func(repos *Repositories) error) (err error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if _, err = tx.Exec(markUnitOfWork); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+
+	if err = fn(NewRepositories(tx)); err != nil {
+		tx.Rollback()
+		return translateError(err)
+	}
+
+	if _, err = tx.Exec(bumpDataVersion); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error updating data version: %w", translateError(err))
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", translateError(err))
+	}
+
+	return nil
+}
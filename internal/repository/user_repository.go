@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"football-management-api/internal/models"
+	"time"
+)
+
+type UserRepository interface {
+	Create(user *models.User) error
+	FindByID(id int) (*models.User, error)
+	FindByUsername(username string) (*models.User, error)
+	FindByEmail(email string) (*models.User, error)
+	FindAll(limit, offset int) ([]models.User, int64, error)
+	UpdateRole(id int, role models.UserRole, teamID sql.NullInt64) error
+}
+
+type userRepository struct {
+	db DBTX
+}
+
+func NewUserRepository(db DBTX) UserRepository {
+	return &userRepository{db: db}
+}
+
+const userColumns = `id, username, email, password_hash, role, team_id, created_at, updated_at`
+
+// Create creates a new user
+func (r *userRepository) Create(user *models.User) error {
+	query := `
+		INSERT INTO users (username, email, password_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, role, created_at, updated_at
+	`
+
+	now := time.Now()
+	return r.db.QueryRow(query,
+		user.Username,
+		user.Email,
+		user.PasswordHash,
+		now,
+		now,
+	).Scan(&user.ID, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+}
+
+// FindByID finds a user by ID
+func (r *userRepository) FindByID(id int) (*models.User, error) {
+	user, err := r.findOne(`SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// FindByUsername finds a user by username, returning nil when there is none
+func (r *userRepository) FindByUsername(username string) (*models.User, error) {
+	return r.findOne(`SELECT `+userColumns+` FROM users WHERE LOWER(username) = LOWER($1)`, username)
+}
+
+// FindByEmail finds a user by email, returning nil when there is none
+func (r *userRepository) FindByEmail(email string) (*models.User, error) {
+	return r.findOne(`SELECT `+userColumns+` FROM users WHERE LOWER(email) = LOWER($1)`, email)
+}
+
+// FindAll finds users ordered by username with pagination
+func (r *userRepository) FindAll(limit, offset int) ([]models.User, int64, error) {
+	var total int64
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM users`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`SELECT `+userColumns+` FROM users ORDER BY username LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var user models.User
+		if err := scanUser(rows, &user); err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+
+	return users, total, rows.Err()
+}
+
+// UpdateRole changes the role of a user. teamID must be set for team managers only.
+func (r *userRepository) UpdateRole(id int, role models.UserRole, teamID sql.NullInt64) error {
+	query := `
+		UPDATE users
+		SET role = $1, team_id = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.Exec(query, role, teamID, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// findOne scans a single user, returning nil when the query has no rows
+func (r *userRepository) findOne(query string, arg interface{}) (*models.User, error) {
+	var user models.User
+	err := scanUser(r.db.QueryRow(query, arg), &user)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanUser scans the userColumns of a row
+func scanUser(row rowScanner, user *models.User) error {
+	return row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Role,
+		&user.TeamID,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+}
@@ -6,6 +6,8 @@ import (
 	"football-management-api/internal/middleware"
 	"football-management-api/internal/repository"
 	"football-management-api/internal/service"
+	"football-management-api/internal/utils"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,9 +22,11 @@ func SetupRouter() *gin.Engine {
 	}
 
 	router := gin.New()
+	utils.RegisterBindingFieldNames()
 
 	// Apply middleware
 	router.Use(middleware.Recovery())
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger())
 	router.Use(middleware.CORS())
 	router.Use(middleware.ErrorHandler())
@@ -34,13 +38,26 @@ func SetupRouter() *gin.Engine {
 	matchRepo := repository.NewMatchRepository(db)
 	goalRepo := repository.NewGoalRepository(db)
 	reportRepo := repository.NewReportRepository(db)
+	searchRepo := repository.NewSearchRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	tokenRepo := repository.NewTokenRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	uow := repository.NewUnitOfWork(db)
 
 	// Initialize services
-	teamService := service.NewTeamService(teamRepo)
-	playerService := service.NewPlayerService(playerRepo, teamRepo)
-	matchService := service.NewMatchService(matchRepo, teamRepo, playerRepo, goalRepo)
-	goalService := service.NewGoalService(goalRepo, matchRepo, playerRepo)
+	teamService := service.NewTeamService(uow, teamRepo, playerRepo, matchRepo, goalRepo)
+	playerService := service.NewPlayerService(uow, playerRepo, teamRepo, matchRepo, goalRepo)
+	matchService := service.NewMatchService(uow, matchRepo, teamRepo, playerRepo, goalRepo)
+	goalService := service.NewGoalService(uow, goalRepo, matchRepo, playerRepo)
 	reportService := service.NewReportService(reportRepo, matchRepo, teamRepo, playerRepo)
+	searchService := service.NewSearchService(searchRepo)
+	authService := service.NewAuthService(uow, userRepo, tokenRepo)
+	userService := service.NewUserService(uow, userRepo)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
+	auditService := service.NewAuditService(auditRepo)
+	trashService := service.NewTrashService(uow, trashRepo, config.GlobalConfig.Trash.RetentionDays)
 
 	// Initialize handlers
 	teamHandler := handler.NewTeamHandler(teamService)
@@ -48,6 +65,24 @@ func SetupRouter() *gin.Engine {
 	matchHandler := handler.NewMatchHandler(matchService)
 	goalHandler := handler.NewGoalHandler(goalService)
 	reportHandler := handler.NewReportHandler(reportService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	authHandler := handler.NewAuthHandler(authService)
+	userHandler := handler.NewUserHandler(userService)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+	jwksHandler := handler.NewJWKSHandler()
+	auditHandler := handler.NewAuditHandler(auditService)
+	trashHandler := handler.NewTrashHandler(trashService)
+
+	// Purge deleted records past their retention period in the background
+	service.StartTrashPurge(trashService, time.Duration(config.GlobalConfig.Trash.PurgeInterval)*time.Hour)
+
+	// Reads are public, every write requires a signed-in user whose role allows it or an
+	// API key whose scopes allow it
+	router.Use(middleware.APIKeyAuth(apiKeyService.Authenticate, service.ErrInvalidAPIKey))
+	requireAuth := middleware.AuthMiddleware(authService.IsTokenRevoked)
+
+	// Public keys other services verify our access tokens with
+	router.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -61,54 +96,109 @@ func SetupRouter() *gin.Engine {
 			})
 		})
 
+		// Auth routes
+		authRoutes := v1.Group("/auth")
+		{
+			authRoutes.POST("/register", authHandler.Register)
+			authRoutes.POST("/login", authHandler.Login)
+			authRoutes.POST("/refresh", authHandler.Refresh)
+			authRoutes.POST("/logout", requireAuth, authHandler.Logout)
+			authRoutes.GET("/me", requireAuth, authHandler.Me)
+		}
+
+		// Users routes (admin only)
+		users := v1.Group("/users", requireAuth)
+		{
+			users.GET("", userHandler.GetAll)
+			users.PUT("/:id/role", userHandler.UpdateRole)
+		}
+
+		// API keys routes (admin only)
+		apiKeys := v1.Group("/api-keys", requireAuth)
+		{
+			apiKeys.GET("", apiKeyHandler.GetAll)
+			apiKeys.POST("", apiKeyHandler.Create)
+			apiKeys.DELETE("/:id", apiKeyHandler.Revoke)
+		}
+
+		// Audit log (admin only)
+		v1.GET("/audit", requireAuth, auditHandler.GetAll)
+
+		// Trash routes (admin only)
+		trash := v1.Group("/trash", requireAuth)
+		{
+			trash.POST("/purge", trashHandler.PurgeExpired)
+			trash.GET("/:entity", trashHandler.GetAll)
+			trash.POST("/:entity/:id/restore", trashHandler.Restore)
+			trash.DELETE("/:entity/:id", trashHandler.Purge)
+		}
+
 		// Teams routes
 		teams := v1.Group("/teams")
 		{
-			teams.POST("", teamHandler.Create)
+			teams.POST("", requireAuth, teamHandler.Create)
 			teams.GET("", teamHandler.GetAll)
 			teams.GET("/:id", teamHandler.GetByID)
-			teams.PUT("/:id", teamHandler.Update)
-			teams.DELETE("/:id", teamHandler.Delete)
+			teams.PUT("/:id", requireAuth, teamHandler.Update)
+			teams.PATCH("/:id", requireAuth, teamHandler.Patch)
+			teams.DELETE("/:id", requireAuth, teamHandler.Delete)
 			teams.GET("/:id/players", playerHandler.GetByTeamID)
+			teams.GET("/:id/matches", matchHandler.GetByTeamID)
 		}
 
 		// Players routes
 		players := v1.Group("/players")
 		{
-			players.POST("", playerHandler.Create)
+			players.POST("", requireAuth, playerHandler.Create)
 			players.GET("", playerHandler.GetAll)
 			players.GET("/:id", playerHandler.GetByID)
-			players.PUT("/:id", playerHandler.Update)
-			players.DELETE("/:id", playerHandler.Delete)
+			players.GET("/:id/matches", playerHandler.GetMatches)
+			players.PUT("/:id", requireAuth, playerHandler.Update)
+			players.PATCH("/:id", requireAuth, playerHandler.Patch)
+			players.DELETE("/:id", requireAuth, playerHandler.Delete)
 		}
 
 		// Matches routes
 		matches := v1.Group("/matches")
 		{
-			matches.POST("", matchHandler.Create)
+			matches.POST("", requireAuth, matchHandler.Create)
 			matches.GET("", matchHandler.GetAll)
 			matches.GET("/:id", matchHandler.GetByID)
-			matches.PUT("/:id", matchHandler.Update)
-			matches.DELETE("/:id", matchHandler.Delete)
-			matches.PUT("/:id/result", matchHandler.UpdateResult)
+			matches.PUT("/:id", requireAuth, matchHandler.Update)
+			matches.PATCH("/:id", requireAuth, matchHandler.Patch)
+			matches.DELETE("/:id", requireAuth, matchHandler.Delete)
+			matches.PUT("/:id/result", requireAuth, matchHandler.UpdateResult)
 			matches.GET("/:id/goals", goalHandler.GetByMatchID)
 		}
 
 		// Goals routes
 		goals := v1.Group("/goals")
 		{
-			goals.POST("", goalHandler.Create)
-			goals.DELETE("/:id", goalHandler.Delete)
+			goals.GET("", goalHandler.GetAll)
+			goals.GET("/:id", goalHandler.GetByID)
+			goals.POST("", requireAuth, goalHandler.Create)
+			goals.PUT("/:id", requireAuth, goalHandler.Update)
+			goals.DELETE("/:id", requireAuth, goalHandler.Delete)
 		}
 
-		// Reports routes
-		reports := v1.Group("/reports")
+		// Reports routes, cacheable until matches, goals, players or teams change. API keys
+		// need the read:reports scope.
+		reports := v1.Group("/reports",
+			middleware.RequireScope(config.ScopeReadReports),
+			middleware.ConditionalGet(reportService.DataVersion, config.GlobalConfig.Cache.ReportMaxAge),
+		)
 		{
 			reports.GET("/matches/:id", reportHandler.GetMatchReport)
 			reports.GET("/teams/:id/statistics", reportHandler.GetTeamStatistics)
+			reports.GET("/teams/:id/form", reportHandler.GetTeamForm)
 			reports.GET("/players/:id/statistics", reportHandler.GetPlayerStatistics)
 			reports.GET("/top-scorers", reportHandler.GetTopScorers)
+			reports.GET("/head-to-head", reportHandler.GetHeadToHead)
+			reports.GET("/clean-sheets", reportHandler.GetCleanSheets)
 		}
+
+		// Search routes
+		v1.GET("/search", searchHandler.Search)
 	}
 
 	return router
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"database/sql"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/auth"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
+	"football-management-api/internal/repository"
+	"football-management-api/internal/utils"
+	"time"
+)
+
+// ErrInvalidAPIKey is returned for unknown, expired or revoked API keys
+var ErrInvalidAPIKey = apperror.Unauthorized("INVALID_API_KEY", "API key tidak valid, sudah kedaluwarsa, atau sudah dicabut")
+
+type APIKeyService interface {
+	Create(actor policy.Actor, req dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedResponse, error)
+	GetAll(actor policy.Actor) ([]dto.APIKeyResponse, error)
+	Revoke(actor policy.Actor, id int) error
+	Authenticate(key string) (policy.Actor, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository) APIKeyService {
+	return &apiKeyService{apiKeyRepo: apiKeyRepo}
+}
+
+// Create issues a new API key. The key is only part of this response; afterwards just its
+// prefix is known.
+func (s *apiKeyService) Create(actor policy.Actor, req dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedResponse, error) {
+	if err := policy.CanManageAPIKeys(actor); err != nil {
+		return nil, err
+	}
+
+	key, prefix, keyHash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &models.APIKey{
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    uniqueScopes(req.Scopes),
+		CreatedBy: sql.NullInt64{Int64: int64(actor.UserID), Valid: actor.UserID != 0},
+	}
+	if req.ExpiresInDays > 0 {
+		apiKey.ExpiresAt = sql.NullTime{Time: time.Now().AddDate(0, 0, req.ExpiresInDays), Valid: true}
+	}
+
+	if err := s.apiKeyRepo.Create(apiKey); err != nil {
+		return nil, err
+	}
+
+	return &dto.APIKeyCreatedResponse{
+		APIKeyResponse: *toAPIKeyResponse(apiKey),
+		Key:            key,
+	}, nil
+}
+
+// GetAll gets all API keys, including revoked and expired ones
+func (s *apiKeyService) GetAll(actor policy.Actor) ([]dto.APIKeyResponse, error) {
+	if err := policy.CanManageAPIKeys(actor); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeyRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	responses := []dto.APIKeyResponse{}
+	for _, key := range keys {
+		responses = append(responses, *toAPIKeyResponse(&key))
+	}
+
+	return responses, nil
+}
+
+// Revoke revokes an API key. Requests using the key are rejected immediately.
+func (s *apiKeyService) Revoke(actor policy.Actor, id int) error {
+	if err := policy.CanManageAPIKeys(actor); err != nil {
+		return err
+	}
+
+	return s.apiKeyRepo.Revoke(id)
+}
+
+// Authenticate resolves an API key to the actor it acts as and records its use
+func (s *apiKeyService) Authenticate(key string) (policy.Actor, error) {
+	prefix, ok := auth.ParseAPIKey(key)
+	if !ok {
+		return policy.Actor{}, ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKeyRepo.FindByPrefix(prefix)
+	if err != nil {
+		return policy.Actor{}, err
+	}
+
+	if apiKey == nil || !auth.CheckAPIKey(key, apiKey.KeyHash) || !apiKey.IsActive() {
+		return policy.Actor{}, ErrInvalidAPIKey
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(apiKey.ID); err != nil {
+		return policy.Actor{}, err
+	}
+
+	return policy.Actor{
+		Username: apiKey.Name,
+		APIKeyID: apiKey.ID,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
+
+// uniqueScopes removes duplicate scopes, keeping their order
+func uniqueScopes(scopes []string) []string {
+	unique := []string{}
+	for _, scope := range scopes {
+		if !utils.Contains(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
+
+// toAPIKeyResponse converts API key model to response DTO
+func toAPIKeyResponse(key *models.APIKey) *dto.APIKeyResponse {
+	return &dto.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  utils.NullTimeToStringPtr(key.ExpiresAt),
+		LastUsedAt: utils.NullTimeToStringPtr(key.LastUsedAt),
+		RevokedAt:  utils.NullTimeToStringPtr(key.RevokedAt),
+		CreatedBy:  utils.NullInt64ToIntPtr(key.CreatedBy),
+		CreatedAt:  utils.FormatDateTime(key.CreatedAt),
+	}
+}
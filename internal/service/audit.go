@@ -0,0 +1,93 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
+	"football-management-api/internal/repository"
+	"reflect"
+)
+
+// auditIgnoredFields change with every write and would only clutter the diff
+var auditIgnoredFields = map[string]bool{"updated_at": true}
+
+// recordAudit stores an audit entry for a change of an entity in the transaction of repos.
+// before is nil for a create and after is nil for a delete. Both are response DTOs, so the
+// entry shows the fields as clients see them.
+func recordAudit(repos *repository.Repositories, actor policy.Actor, entity models.AuditEntity, entityID int, before, after interface{}) error {
+	action := models.AuditActionUpdate
+	switch {
+	case before == nil:
+		action = models.AuditActionCreate
+	case after == nil:
+		action = models.AuditActionDelete
+	}
+
+	return recordAuditAction(repos, actor, action, entity, entityID, before, after)
+}
+
+// recordAuditAction stores an audit entry for an action that cannot be told from before and
+// after alone, such as a restore
+func recordAuditAction(repos *repository.Repositories, actor policy.Actor, action models.AuditAction, entity models.AuditEntity, entityID int, before, after interface{}) error {
+	changes, err := diffFields(before, after)
+	if err != nil {
+		return err
+	}
+
+	return repos.Audit.Create(&models.AuditLog{
+		Entity:        entity,
+		EntityID:      entityID,
+		Action:        action,
+		ActorUserID:   sql.NullInt64{Int64: int64(actor.UserID), Valid: actor.UserID != 0},
+		ActorAPIKeyID: sql.NullInt64{Int64: int64(actor.APIKeyID), Valid: actor.APIKeyID != 0},
+		ActorName:     actor.Username,
+		RequestID:     actor.RequestID,
+		Changes:       changes,
+	})
+}
+
+// diffFields returns the JSON fields whose value differs between before and after. A field
+// missing on one side counts as null, so a create or delete does not list fields that are null.
+func diffFields(before, after interface{}) (map[string]models.FieldChange, error) {
+	beforeFields, err := toFieldMap(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFieldMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]models.FieldChange{}
+	for field, value := range beforeFields {
+		if !auditIgnoredFields[field] && !reflect.DeepEqual(value, afterFields[field]) {
+			changes[field] = models.FieldChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok && value != nil && !auditIgnoredFields[field] {
+			changes[field] = models.FieldChange{Before: nil, After: value}
+		}
+	}
+
+	return changes, nil
+}
+
+// toFieldMap decodes the JSON representation of v into its fields
+func toFieldMap(v interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if v == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
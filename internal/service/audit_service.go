@@ -0,0 +1,78 @@
+package service
+
+import (
+	"football-management-api/internal/dto"
+	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
+	"football-management-api/internal/repository"
+	"football-management-api/internal/utils"
+)
+
+type AuditService interface {
+	GetAll(actor policy.Actor, query dto.AuditListQuery, page, limit int) ([]dto.AuditLogResponse, dto.PaginationMeta, error)
+}
+
+type auditService struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// GetAll gets audit entries matching the query, newest first
+func (s *auditService) GetAll(actor policy.Actor, query dto.AuditListQuery, page, limit int) ([]dto.AuditLogResponse, dto.PaginationMeta, error) {
+	if err := policy.CanViewAudit(actor); err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	filter := models.AuditFilter{
+		Entity:   query.Entity,
+		EntityID: query.EntityID,
+		Actor:    query.Actor,
+		DateFrom: query.DateFrom,
+		DateTo:   query.DateTo,
+	}
+
+	offset := utils.CalculateOffset(page, limit)
+
+	entries, total, err := s.auditRepo.FindAll(filter, limit, offset)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	responses := []dto.AuditLogResponse{}
+	for _, entry := range entries {
+		responses = append(responses, toAuditLogResponse(&entry))
+	}
+
+	meta := dto.PaginationMeta{
+		CurrentPage: page,
+		PerPage:     limit,
+		Total:       total,
+		TotalPages:  utils.CalculateTotalPages(total, limit),
+	}
+
+	return responses, meta, nil
+}
+
+// toAuditLogResponse maps audit entry model to response DTO
+func toAuditLogResponse(entry *models.AuditLog) dto.AuditLogResponse {
+	changes := map[string]dto.FieldChange{}
+	for field, change := range entry.Changes {
+		changes[field] = dto.FieldChange{Before: change.Before, After: change.After}
+	}
+
+	return dto.AuditLogResponse{
+		ID:            entry.ID,
+		Entity:        string(entry.Entity),
+		EntityID:      entry.EntityID,
+		Action:        string(entry.Action),
+		ActorUserID:   utils.NullInt64ToIntPtr(entry.ActorUserID),
+		ActorAPIKeyID: utils.NullInt64ToIntPtr(entry.ActorAPIKeyID),
+		ActorName:     entry.ActorName,
+		RequestID:     entry.RequestID,
+		Changes:       changes,
+		CreatedAt:     utils.FormatDateTime(entry.CreatedAt),
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"football-management-api/internal/models"
+)
+
+func TestDiffFields(t *testing.T) {
+	type team struct {
+		ID        int      `json:"id"`
+		Name      string   `json:"name"`
+		LogoURL   *string  `json:"logo_url"`
+		Tags      []string `json:"tags,omitempty"`
+		UpdatedAt string   `json:"updated_at"`
+	}
+
+	logo := "https://example.com/logo.png"
+	persija := team{ID: 1, Name: "Persija", UpdatedAt: "2024-10-01"}
+
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   map[string]models.FieldChange
+	}{
+		{
+			name:  "create lists every field that is set",
+			after: persija,
+			want: map[string]models.FieldChange{
+				"id":   {Before: nil, After: float64(1)},
+				"name": {Before: nil, After: "Persija"},
+			},
+		},
+		{
+			name:   "delete lists every field that was set",
+			before: persija,
+			want: map[string]models.FieldChange{
+				"id":   {Before: float64(1), After: nil},
+				"name": {Before: "Persija", After: nil},
+			},
+		},
+		{
+			name:   "update lists changed fields only",
+			before: persija,
+			after:  team{ID: 1, Name: "Persija Jakarta", LogoURL: &logo, UpdatedAt: "2024-10-02"},
+			want: map[string]models.FieldChange{
+				"name":     {Before: "Persija", After: "Persija Jakarta"},
+				"logo_url": {Before: nil, After: logo},
+			},
+		},
+		{
+			name:   "field only present after the change",
+			before: persija,
+			after:  team{ID: 1, Name: "Persija", Tags: []string{"jakarta"}},
+			want: map[string]models.FieldChange{
+				"tags": {Before: nil, After: []interface{}{"jakarta"}},
+			},
+		},
+		{
+			name:   "updated_at alone is no change",
+			before: persija,
+			after:  team{ID: 1, Name: "Persija", UpdatedAt: "2024-10-02"},
+			want:   map[string]models.FieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diffFields(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("diffFields() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/auth"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/models"
+	"football-management-api/internal/repository"
+	"football-management-api/internal/utils"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCredentials is returned when a login does not match any user. Unknown
+// usernames and wrong passwords are reported the same way.
+var ErrInvalidCredentials = apperror.Unauthorized("INVALID_CREDENTIALS", "username atau password salah")
+
+// ErrInvalidRefreshToken is returned for unknown, expired or revoked refresh tokens
+var ErrInvalidRefreshToken = apperror.Unauthorized("INVALID_REFRESH_TOKEN", "refresh token tidak valid atau sudah kedaluwarsa")
+
+// ErrRefreshTokenReused is returned when a refresh token that was already rotated is used
+// again. The token may have been stolen, so the whole session is revoked.
+var ErrRefreshTokenReused = apperror.Unauthorized("REFRESH_TOKEN_REUSED", "refresh token sudah pernah digunakan, sesi dicabut dan harus login ulang")
+
+// Errors returned when a registration conflicts with an existing user
+var (
+	ErrUsernameTaken = repository.ErrUsernameTaken
+	ErrEmailTaken    = repository.ErrEmailTaken
+)
+
+type AuthService interface {
+	Register(req dto.RegisterRequest) (*dto.UserResponse, error)
+	Login(req dto.LoginRequest) (*dto.TokenResponse, error)
+	Refresh(req dto.RefreshTokenRequest) (*dto.TokenResponse, error)
+	Logout(claims *auth.Claims) error
+	IsTokenRevoked(claims *auth.Claims) (bool, error)
+	GetUser(id int) (*dto.UserResponse, error)
+}
+
+type authService struct {
+	uow       repository.UnitOfWork
+	userRepo  repository.UserRepository
+	tokenRepo repository.TokenRepository
+}
+
+func NewAuthService(
+	uow repository.UnitOfWork,
+	userRepo repository.UserRepository,
+	tokenRepo repository.TokenRepository,
+) AuthService {
+	return &authService{
+		uow:       uow,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+	}
+}
+
+// Register creates a new user account
+func (s *authService) Register(req dto.RegisterRequest) (*dto.UserResponse, error) {
+	passwordHash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username:     req.Username,
+		Email:        strings.ToLower(req.Email),
+		PasswordHash: passwordHash,
+	}
+
+	err = s.uow.Do(func(repos *repository.Repositories) error {
+		existingUser, err := repos.Users.FindByUsername(user.Username)
+		if err != nil {
+			return err
+		}
+		if existingUser != nil {
+			return ErrUsernameTaken
+		}
+
+		existingUser, err = repos.Users.FindByEmail(user.Email)
+		if err != nil {
+			return err
+		}
+		if existingUser != nil {
+			return ErrEmailTaken
+		}
+
+		return repos.Users.Create(user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+// Login checks the credentials and starts a new session with an access and a refresh token
+func (s *authService) Login(req dto.LoginRequest) (*dto.TokenResponse, error) {
+	user, err := s.userRepo.FindByUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !auth.CheckPassword(user.PasswordHash, req.Password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	familyID, err := auth.NewFamilyID()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(s.tokenRepo, user, familyID)
+}
+
+// Refresh rotates a refresh token: the token is used up and a new access and refresh token of
+// the same session are issued. Using a rotated token again revokes the whole session.
+func (s *authService) Refresh(req dto.RefreshTokenRequest) (*dto.TokenResponse, error) {
+	var response *dto.TokenResponse
+	reused := false
+
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		token, err := repos.Tokens.FindRefreshTokenByHash(auth.HashToken(req.RefreshToken))
+		if err != nil {
+			return err
+		}
+		if token == nil || token.RevokedAt.Valid || time.Now().After(token.ExpiresAt) {
+			return ErrInvalidRefreshToken
+		}
+
+		if !token.UsedAt.Valid {
+			marked, err := repos.Tokens.MarkRefreshTokenUsed(token.ID)
+			if err != nil {
+				return err
+			}
+			reused = !marked
+		} else {
+			reused = true
+		}
+		if reused {
+			// Commit the revocation, the error is returned after the transaction
+			return repos.Tokens.RevokeFamily(token.FamilyID)
+		}
+
+		// Read the user again so role changes apply from the next token on
+		user, err := repos.Users.FindByID(token.UserID)
+		if err != nil {
+			return ErrInvalidRefreshToken
+		}
+
+		response, err = s.issueTokens(repos.Tokens, user, token.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, ErrRefreshTokenReused
+	}
+
+	return response, nil
+}
+
+// Logout ends the session of an access token. The token and every refresh token of its session
+// stop working immediately.
+func (s *authService) Logout(claims *auth.Claims) error {
+	return s.uow.Do(func(repos *repository.Repositories) error {
+		if err := repos.Tokens.RevokeAccessToken(claims.ID, claims.ExpiresAt.Time); err != nil {
+			return err
+		}
+		if err := repos.Tokens.RevokeFamily(claims.FamilyID); err != nil {
+			return err
+		}
+
+		return repos.Tokens.DeleteExpired()
+	})
+}
+
+// IsTokenRevoked reports whether an access token was revoked by logout or refresh token reuse
+func (s *authService) IsTokenRevoked(claims *auth.Claims) (bool, error) {
+	return s.tokenRepo.IsRevoked(claims.ID, claims.FamilyID)
+}
+
+// issueTokens issues an access token and stores a new refresh token of the session
+func (s *authService) issueTokens(tokens repository.TokenRepository, user *models.User, familyID string) (*dto.TokenResponse, error) {
+	refreshToken, refreshTokenHash, refreshExpiresAt, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tokens.CreateRefreshToken(&models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: refreshTokenHash,
+		ExpiresAt: refreshExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, expiresAt, err := auth.GenerateToken(user, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TokenResponse{
+		AccessToken:           accessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int(time.Until(expiresAt).Seconds()),
+		ExpiresAt:             utils.FormatDateTime(expiresAt),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: utils.FormatDateTime(refreshExpiresAt),
+		User:                  *toUserResponse(user),
+	}, nil
+}
+
+// GetUser gets the user of an authenticated request
+func (s *authService) GetUser(id int) (*dto.UserResponse, error) {
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserResponse(user), nil
+}
+
+// toUserResponse maps user model to response DTO
+func toUserResponse(user *models.User) *dto.UserResponse {
+	return &dto.UserResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		Role:      string(user.Role),
+		TeamID:    utils.NullInt64ToIntPtr(user.TeamID),
+		CreatedAt: utils.FormatDateTime(user.CreatedAt),
+	}
+}
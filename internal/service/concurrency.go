@@ -0,0 +1,17 @@
+package service
+
+import "football-management-api/internal/repository"
+
+// ErrVersionConflict is returned when a resource was changed since the client read it
+var ErrVersionConflict = repository.ErrVersionConflict
+
+// AnyVersion matches every version of a resource (If-Match: *)
+const AnyVersion = 0
+
+// checkVersion verifies that the version the client read is still the current one
+func checkVersion(expected, current int) error {
+	if expected != AnyVersion && expected != current {
+		return ErrVersionConflict
+	}
+	return nil
+}
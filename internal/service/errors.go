@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/repository"
+)
+
+// Errors returned when a write conflicts with an active record
+var (
+	ErrTeamNameTaken     = repository.ErrTeamNameTaken
+	ErrJerseyNumberTaken = repository.ErrJerseyNumberTaken
+)
+
+// Errors returned when a request body references a record that does not exist. Unlike the
+// not found errors of the repositories, the request is invalid rather than its URL.
+var (
+	ErrUnknownTeam     = apperror.Validation("UNKNOWN_TEAM", "tim tidak ditemukan")
+	ErrUnknownHomeTeam = apperror.Validation("UNKNOWN_HOME_TEAM", "tim home tidak ditemukan")
+	ErrUnknownAwayTeam = apperror.Validation("UNKNOWN_AWAY_TEAM", "tim away tidak ditemukan")
+	ErrUnknownPlayer   = apperror.Validation("UNKNOWN_PLAYER", "pemain tidak ditemukan")
+	ErrUnknownMatch    = apperror.Validation("UNKNOWN_MATCH", "pertandingan tidak ditemukan")
+)
+
+// ErrPlayerNotInMatch is returned when a goal is credited to a player of neither team
+var ErrPlayerNotInMatch = apperror.Validation("PLAYER_NOT_IN_MATCH", "pemain tidak bermain dalam pertandingan ini")
+
+// ErrSameTeams is returned when a team would be compared with or play against itself
+var ErrSameTeams = apperror.Validation("SAME_TEAMS", "tim home dan away tidak boleh sama")
+
+// referenced replaces the not found error of a record referenced by a request body with
+// invalid. Other errors are returned unchanged.
+func referenced(err error, notFound, invalid error) error {
+	if err != nil && errors.Is(err, notFound) {
+		return invalid
+	}
+	return err
+}
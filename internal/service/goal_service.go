@@ -1,63 +1,88 @@
 package service
 
 import (
-	"errors"
+	"football-management-api/internal/apperror"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
 	"football-management-api/internal/repository"
 	"football-management-api/internal/utils"
 )
 
+// ErrMatchNotStarted is returned when goals are recorded for a match that has not started
+var ErrMatchNotStarted = apperror.StateTransition("MATCH_NOT_STARTED", "gol hanya dapat dicatat untuk pertandingan yang sedang berlangsung atau sudah selesai")
+
 type GoalService interface {
-	Create(req dto.CreateGoalRequest) (*dto.GoalResponse, error)
+	Create(actor policy.Actor, req dto.CreateGoalRequest) (*dto.GoalResponse, error)
+	GetAll(query dto.GoalListQuery, page, limit int) ([]dto.GoalResponse, dto.PaginationMeta, error)
+	GetByID(id int) (*dto.GoalResponse, error)
 	GetByMatchID(matchID int) ([]dto.GoalResponse, error)
-	Delete(id int) error
+	Update(actor policy.Actor, id, version int, req dto.UpdateGoalRequest) (*dto.GoalResponse, error)
+	Delete(actor policy.Actor, id, version int) error
 }
 
 type goalService struct {
+	uow        repository.UnitOfWork
 	goalRepo   repository.GoalRepository
 	matchRepo  repository.MatchRepository
 	playerRepo repository.PlayerRepository
 }
 
 func NewGoalService(
+	uow repository.UnitOfWork,
 	goalRepo repository.GoalRepository,
 	matchRepo repository.MatchRepository,
 	playerRepo repository.PlayerRepository,
 ) GoalService {
 	return &goalService{
+		uow:        uow,
 		goalRepo:   goalRepo,
 		matchRepo:  matchRepo,
 		playerRepo: playerRepo,
 	}
 }
 
-// Create creates a new goal
-func (s *goalService) Create(req dto.CreateGoalRequest) (*dto.GoalResponse, error) {
-	// Validate match exists
-	match, err := s.matchRepo.FindByID(req.MatchID)
-	if err != nil {
-		return nil, errors.New("pertandingan tidak ditemukan")
-	}
-
-	// Validate player exists
-	player, err := s.playerRepo.FindByID(req.PlayerID)
-	if err != nil {
-		return nil, errors.New("pemain tidak ditemukan")
-	}
-
-	// Validate player is in one of the teams playing
-	if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
-		return nil, errors.New("pemain tidak bermain dalam pertandingan ini")
-	}
-
+// Create creates a new goal and updates the match score
+func (s *goalService) Create(actor policy.Actor, req dto.CreateGoalRequest) (*dto.GoalResponse, error) {
 	goal := &models.Goal{
 		MatchID:  req.MatchID,
 		PlayerID: req.PlayerID,
 		GoalTime: req.GoalTime,
 	}
 
-	err = s.goalRepo.Create(goal)
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		// Validate match exists and has started
+		match, err := repos.Matches.FindByID(req.MatchID)
+		if err != nil {
+			return referenced(err, repository.ErrMatchNotFound, ErrUnknownMatch)
+		}
+		if err := policy.CanRecordGoals(actor, match); err != nil {
+			return err
+		}
+		if !match.HasStarted() {
+			return ErrMatchNotStarted
+		}
+
+		player, err := validatePlayerInMatch(repos, req.PlayerID, match)
+		if err != nil {
+			return err
+		}
+		goal.TeamID = player.TeamID
+
+		if err := repos.Goals.Create(goal); err != nil {
+			return err
+		}
+
+		createdGoal, err := repos.Goals.FindByID(goal.ID)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, models.AuditEntityGoal, goal.ID, nil, toGoalResponse(createdGoal)); err != nil {
+			return err
+		}
+
+		return recalculateScore(repos, actor, match)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +93,35 @@ func (s *goalService) Create(req dto.CreateGoalRequest) (*dto.GoalResponse, erro
 		return nil, err
 	}
 
-	return s.mapToResponse(createdGoal), nil
+	return toGoalResponse(createdGoal), nil
+}
+
+// GetAll gets goals matching the query with offset or cursor pagination
+func (s *goalService) GetAll(query dto.GoalListQuery, page, limit int) ([]dto.GoalResponse, dto.PaginationMeta, error) {
+	filter := models.GoalFilter{
+		MatchID:  query.MatchID,
+		PlayerID: query.PlayerID,
+		TeamID:   query.TeamID,
+	}
+
+	opts, err := utils.NewListOptions(query.Search, query.Sort, query.Cursor, page, limit)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	goals, info, err := s.goalRepo.FindAll(filter, opts)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	var responses []dto.GoalResponse
+	for _, goal := range goals {
+		responses = append(responses, *toGoalResponse(&goal))
+	}
+
+	meta := utils.NewPaginationMeta(info, opts, page)
+
+	return responses, meta, nil
 }
 
 // GetByMatchID gets all goals in a match
@@ -76,7 +129,7 @@ func (s *goalService) GetByMatchID(matchID int) ([]dto.GoalResponse, error) {
 	// Validate match exists
 	_, err := s.matchRepo.FindByID(matchID)
 	if err != nil {
-		return nil, errors.New("pertandingan tidak ditemukan")
+		return nil, err
 	}
 
 	goals, err := s.goalRepo.FindByMatchID(matchID)
@@ -86,25 +139,152 @@ func (s *goalService) GetByMatchID(matchID int) ([]dto.GoalResponse, error) {
 
 	var responses []dto.GoalResponse
 	for _, goal := range goals {
-		responses = append(responses, *s.mapToResponse(&goal))
+		responses = append(responses, *toGoalResponse(&goal))
 	}
 
 	return responses, nil
 }
 
-// Delete deletes a goal
-func (s *goalService) Delete(id int) error {
-	return s.goalRepo.Delete(id)
+// GetByID gets a goal by ID
+func (s *goalService) GetByID(id int) (*dto.GoalResponse, error) {
+	goal, err := s.goalRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGoalResponse(goal), nil
+}
+
+// Update corrects the scorer or time of a goal and updates the match score. The goal must
+// still have the version the client read.
+func (s *goalService) Update(actor policy.Actor, id, version int, req dto.UpdateGoalRequest) (*dto.GoalResponse, error) {
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		goal, err := repos.Goals.FindByID(id)
+		if err != nil {
+			return err
+		}
+
+		match, err := repos.Matches.FindByID(goal.MatchID)
+		if err != nil {
+			return err
+		}
+		if err := policy.CanRecordGoals(actor, match); err != nil {
+			return err
+		}
+		if err := checkVersion(version, goal.Version); err != nil {
+			return err
+		}
+		if !match.HasStarted() {
+			return ErrMatchNotStarted
+		}
+
+		player, err := validatePlayerInMatch(repos, req.PlayerID, match)
+		if err != nil {
+			return err
+		}
+
+		before := toGoalResponse(goal)
+		goal.PlayerID = req.PlayerID
+		goal.TeamID = player.TeamID
+		goal.GoalTime = req.GoalTime
+
+		if err := repos.Goals.Update(id, goal); err != nil {
+			return err
+		}
+
+		updatedGoal, err := repos.Goals.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, models.AuditEntityGoal, id, before, toGoalResponse(updatedGoal)); err != nil {
+			return err
+		}
+
+		return recalculateScore(repos, actor, match)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updatedGoal, err := s.goalRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGoalResponse(updatedGoal), nil
+}
+
+// Delete deletes a goal if it still has the version the client read and updates the match score
+func (s *goalService) Delete(actor policy.Actor, id, version int) error {
+	return s.uow.Do(func(repos *repository.Repositories) error {
+		goal, err := repos.Goals.FindByID(id)
+		if err != nil {
+			return err
+		}
+
+		match, err := repos.Matches.FindByID(goal.MatchID)
+		if err != nil {
+			return err
+		}
+		if err := policy.CanRecordGoals(actor, match); err != nil {
+			return err
+		}
+		if err := checkVersion(version, goal.Version); err != nil {
+			return err
+		}
+
+		if err := repos.Goals.Delete(id, goal.Version); err != nil {
+			return err
+		}
+		if err := recordAudit(repos, actor, models.AuditEntityGoal, id, toGoalResponse(goal), nil); err != nil {
+			return err
+		}
+
+		// Scheduled and cancelled matches have no score to keep in sync
+		if !match.HasStarted() {
+			return nil
+		}
+
+		return recalculateScore(repos, actor, match)
+	})
+}
+
+// recalculateScore recounts the score of the match from its goals and records the new score
+// in the audit log
+func recalculateScore(repos *repository.Repositories, actor policy.Actor, match *models.Match) error {
+	if err := repos.Matches.RecalculateScore(match.ID); err != nil {
+		return err
+	}
+
+	return recordMatchAudit(repos, actor, match.ID, toMatchResponse(match))
+}
+
+// validatePlayerInMatch checks that the player exists and plays for one of the teams in the match.
+// The goal is recorded for the team the player returned plays for.
+func validatePlayerInMatch(repos *repository.Repositories, playerID int, match *models.Match) (*models.Player, error) {
+	player, err := repos.Players.FindByID(playerID)
+	if err != nil {
+		return nil, referenced(err, repository.ErrPlayerNotFound, ErrUnknownPlayer)
+	}
+
+	if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
+		return nil, ErrPlayerNotInMatch
+	}
+
+	return player, nil
 }
 
-// mapToResponse maps goal model to response DTO
-func (s *goalService) mapToResponse(goal *models.Goal) *dto.GoalResponse {
+// toGoalResponse maps goal model to response DTO
+func toGoalResponse(goal *models.Goal) *dto.GoalResponse {
 	response := &dto.GoalResponse{
 		ID:        goal.ID,
 		MatchID:   goal.MatchID,
 		PlayerID:  goal.PlayerID,
+		TeamID:    goal.TeamID,
 		GoalTime:  goal.GoalTime,
+		Version:   goal.Version,
 		CreatedAt: utils.FormatDateTime(goal.CreatedAt),
+		UpdatedAt: utils.FormatDateTime(goal.UpdatedAt),
 	}
 
 	if goal.Player != nil {
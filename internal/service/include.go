@@ -0,0 +1,107 @@
+package service
+
+import (
+	"football-management-api/internal/dto"
+	"football-management-api/internal/repository"
+)
+
+// playersByTeam loads the players of the teams with a single query, grouped by team ID
+func playersByTeam(playerRepo repository.PlayerRepository, teamIDs []int) (map[int][]dto.PlayerResponse, error) {
+	players, err := playerRepo.FindByTeamIDs(teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[int][]dto.PlayerResponse)
+	for i := range players {
+		grouped[players[i].TeamID] = append(grouped[players[i].TeamID], *toPlayerResponseSimple(&players[i]))
+	}
+
+	return grouped, nil
+}
+
+// matchesByTeam loads the matches of the teams with a single query, grouped by team ID.
+// Goals are embedded in every match when withGoals is set.
+func matchesByTeam(matchRepo repository.MatchRepository, goalRepo repository.GoalRepository, teamIDs []int, withGoals bool) (map[int][]dto.MatchResponse, error) {
+	matches, err := matchRepo.FindByTeamIDs(teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.MatchResponse, len(matches))
+	for i := range matches {
+		responses[i] = *toMatchResponse(&matches[i])
+	}
+
+	if withGoals {
+		if err := embedMatchGoals(goalRepo, responses); err != nil {
+			return nil, err
+		}
+	}
+
+	grouped := make(map[int][]dto.MatchResponse)
+	for _, match := range responses {
+		grouped[match.HomeTeamID] = append(grouped[match.HomeTeamID], match)
+		grouped[match.AwayTeamID] = append(grouped[match.AwayTeamID], match)
+	}
+
+	return grouped, nil
+}
+
+// embedMatchGoals loads the goals of the matches with a single query and embeds them
+func embedMatchGoals(goalRepo repository.GoalRepository, matches []dto.MatchResponse) error {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	matchIDs := make([]int, len(matches))
+	for i, match := range matches {
+		matchIDs[i] = match.ID
+	}
+
+	goals, err := goalRepo.FindByMatchIDs(matchIDs)
+	if err != nil {
+		return err
+	}
+
+	grouped := make(map[int][]dto.GoalResponse)
+	for i := range goals {
+		grouped[goals[i].MatchID] = append(grouped[goals[i].MatchID], *toGoalResponse(&goals[i]))
+	}
+
+	for i := range matches {
+		matches[i].Goals = grouped[matches[i].ID]
+	}
+
+	return nil
+}
+
+// goalsByPlayer loads the goals of the players with a single query, grouped by player ID
+func goalsByPlayer(goalRepo repository.GoalRepository, playerIDs []int) (map[int][]dto.GoalResponse, error) {
+	goals, err := goalRepo.FindByPlayerIDs(playerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[int][]dto.GoalResponse)
+	for i := range goals {
+		grouped[goals[i].PlayerID] = append(grouped[goals[i].PlayerID], *toGoalResponse(&goals[i]))
+	}
+
+	return grouped, nil
+}
+
+// teamsByID loads the teams with a single query, keyed by team ID
+func teamsByID(teamRepo repository.TeamRepository, teamIDs []int) (map[int]*dto.TeamResponse, error) {
+	teams, err := teamRepo.FindByIDs(teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	keyed := make(map[int]*dto.TeamResponse)
+	for i := range teams {
+		keyed[teams[i].ID] = toTeamResponse(&teams[i])
+	}
+
+	return keyed, nil
+}
@@ -1,23 +1,39 @@
 package service
 
 import (
-	"errors"
+	"fmt"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
 	"football-management-api/internal/repository"
 	"football-management-api/internal/utils"
+	"football-management-api/internal/validator"
+)
+
+// ErrGoalsScoreMismatch is returned when the goals of a result do not add up to its score
+var ErrGoalsScoreMismatch = apperror.Validation("GOALS_SCORE_MISMATCH", "jumlah gol per tim tidak sesuai dengan skor yang diberikan")
+
+// Errors returned when an update would leave the goals of a match without a valid match
+var (
+	ErrMatchTeamsHaveGoals = apperror.StateTransition("MATCH_HAS_GOALS", "tim pertandingan yang sudah memiliki gol tidak dapat diubah, hapus golnya terlebih dahulu")
+	ErrMatchStatusHasGoals = apperror.StateTransition("MATCH_HAS_GOALS", "pertandingan yang sudah memiliki gol tidak dapat dikembalikan ke status belum dimulai, hapus golnya terlebih dahulu")
 )
 
 type MatchService interface {
-	Create(req dto.CreateMatchRequest) (*dto.MatchResponse, error)
-	GetByID(id int) (*dto.MatchResponse, error)
-	GetAll(page, limit int) ([]dto.MatchResponse, dto.PaginationMeta, error)
-	Update(id int, req dto.UpdateMatchRequest) (*dto.MatchResponse, error)
-	UpdateResult(id int, req dto.UpdateMatchResultRequest) (*dto.MatchResponse, error)
-	Delete(id int) error
+	Create(actor policy.Actor, req dto.CreateMatchRequest) (*dto.MatchResponse, error)
+	GetByID(id int, includes []string) (*dto.MatchResponse, error)
+	GetAll(query dto.MatchListQuery, page, limit int) ([]dto.MatchResponse, dto.PaginationMeta, error)
+	GetByTeamID(teamID int, query dto.TeamMatchesQuery, page, limit int) ([]dto.TeamMatchResponse, dto.PaginationMeta, error)
+	Update(actor policy.Actor, id, version int, req dto.UpdateMatchRequest) (*dto.MatchResponse, error)
+	Patch(actor policy.Actor, id, version int, patch []byte) (*dto.MatchResponse, error)
+	UpdateResult(actor policy.Actor, id, version int, req dto.UpdateMatchResultRequest) (*dto.MatchResponse, error)
+	Delete(actor policy.Actor, id, version int) error
 }
 
 type matchService struct {
+	uow        repository.UnitOfWork
 	matchRepo  repository.MatchRepository
 	teamRepo   repository.TeamRepository
 	playerRepo repository.PlayerRepository
@@ -25,12 +41,14 @@ type matchService struct {
 }
 
 func NewMatchService(
+	uow repository.UnitOfWork,
 	matchRepo repository.MatchRepository,
 	teamRepo repository.TeamRepository,
 	playerRepo repository.PlayerRepository,
 	goalRepo repository.GoalRepository,
 ) MatchService {
 	return &matchService{
+		uow:        uow,
 		matchRepo:  matchRepo,
 		teamRepo:   teamRepo,
 		playerRepo: playerRepo,
@@ -39,16 +57,9 @@ func NewMatchService(
 }
 
 // Create creates a new match
-func (s *matchService) Create(req dto.CreateMatchRequest) (*dto.MatchResponse, error) {
-	// Validate teams exist
-	_, err := s.teamRepo.FindByID(req.HomeTeamID)
-	if err != nil {
-		return nil, errors.New("tim home tidak ditemukan")
-	}
-
-	_, err = s.teamRepo.FindByID(req.AwayTeamID)
-	if err != nil {
-		return nil, errors.New("tim away tidak ditemukan")
+func (s *matchService) Create(actor policy.Actor, req dto.CreateMatchRequest) (*dto.MatchResponse, error) {
+	if err := policy.CanManageMatches(actor); err != nil {
+		return nil, err
 	}
 
 	match := &models.Match{
@@ -59,7 +70,33 @@ func (s *matchService) Create(req dto.CreateMatchRequest) (*dto.MatchResponse, e
 		Status:     models.StatusScheduled,
 	}
 
-	err = s.matchRepo.Create(match)
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		// Validate teams exist and can still play
+		homeTeam, err := repos.Teams.FindByID(req.HomeTeamID)
+		if err != nil {
+			return referenced(err, repository.ErrTeamNotFound, ErrUnknownHomeTeam)
+		}
+
+		awayTeam, err := repos.Teams.FindByID(req.AwayTeamID)
+		if err != nil {
+			return referenced(err, repository.ErrTeamNotFound, ErrUnknownAwayTeam)
+		}
+
+		if homeTeam.IsArchived() || awayTeam.IsArchived() {
+			return ErrTeamArchived
+		}
+
+		if err := repos.Matches.Create(match); err != nil {
+			return err
+		}
+
+		createdMatch, err := repos.Matches.FindByID(match.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(repos, actor, models.AuditEntityMatch, match.ID, nil, toMatchResponse(createdMatch))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -70,31 +107,92 @@ func (s *matchService) Create(req dto.CreateMatchRequest) (*dto.MatchResponse, e
 		return nil, err
 	}
 
-	return s.mapToResponse(createdMatch), nil
+	return toMatchResponse(createdMatch), nil
 }
 
-// GetByID gets a match by ID
-func (s *matchService) GetByID(id int) (*dto.MatchResponse, error) {
+// GetByID gets a match by ID with the requested relations
+func (s *matchService) GetByID(id int, includes []string) (*dto.MatchResponse, error) {
 	match, err := s.matchRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapToResponse(match), nil
+	responses := []dto.MatchResponse{*toMatchResponse(match)}
+	if err := s.embedRelations(responses, includes); err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
 }
 
-// GetAll gets all matches with pagination
-func (s *matchService) GetAll(page, limit int) ([]dto.MatchResponse, dto.PaginationMeta, error) {
-	offset := utils.CalculateOffset(page, limit)
+// GetAll gets matches matching the query with offset or cursor pagination
+func (s *matchService) GetAll(query dto.MatchListQuery, page, limit int) ([]dto.MatchResponse, dto.PaginationMeta, error) {
+	filter := models.MatchFilter{
+		Status:   models.MatchStatus(query.Status),
+		DateFrom: query.DateFrom,
+		DateTo:   query.DateTo,
+	}
 
-	matches, total, err := s.matchRepo.FindAll(limit, offset)
+	opts, err := utils.NewListOptions(query.Search, query.Sort, query.Cursor, page, limit)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	matches, info, err := s.matchRepo.FindAll(filter, opts)
 	if err != nil {
 		return nil, dto.PaginationMeta{}, err
 	}
 
 	var responses []dto.MatchResponse
 	for _, match := range matches {
-		responses = append(responses, *s.mapToResponse(&match))
+		responses = append(responses, *toMatchResponse(&match))
+	}
+
+	if err := s.embedRelations(responses, utils.ParseIncludes(query.Include)); err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	meta := utils.NewPaginationMeta(info, opts, page)
+
+	return responses, meta, nil
+}
+
+// GetByTeamID gets a team's fixtures and results with pagination
+func (s *matchService) GetByTeamID(teamID int, query dto.TeamMatchesQuery, page, limit int) ([]dto.TeamMatchResponse, dto.PaginationMeta, error) {
+	// Validate team exists
+	_, err := s.teamRepo.FindByID(teamID)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	filter := models.MatchFilter{
+		Status:   models.MatchStatus(query.Status),
+		DateFrom: query.DateFrom,
+		DateTo:   query.DateTo,
+	}
+	if query.Home {
+		filter.Venue = config.VenueHome
+	}
+	if query.Away {
+		filter.Venue = config.VenueAway
+	}
+	if query.Upcoming {
+		filter.Period = config.PeriodUpcoming
+	}
+	if query.Past {
+		filter.Period = config.PeriodPast
+	}
+
+	offset := utils.CalculateOffset(page, limit)
+
+	matches, total, err := s.matchRepo.FindByTeamID(teamID, filter, limit, offset)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	responses := []dto.TeamMatchResponse{}
+	for _, match := range matches {
+		responses = append(responses, toTeamMatchResponse(&match, teamID))
 	}
 
 	meta := dto.PaginationMeta{
@@ -107,116 +205,244 @@ func (s *matchService) GetAll(page, limit int) ([]dto.MatchResponse, dto.Paginat
 	return responses, meta, nil
 }
 
-// Update updates a match
-func (s *matchService) Update(id int, req dto.UpdateMatchRequest) (*dto.MatchResponse, error) {
-	// Get existing match
-	existingMatch, err := s.matchRepo.FindByID(id)
+// Update replaces a match's schedule, teams and status if it still has the version the client read
+func (s *matchService) Update(actor policy.Actor, id, version int, req dto.UpdateMatchRequest) (*dto.MatchResponse, error) {
+	if err := policy.CanManageMatches(actor); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		existingMatch, err := repos.Matches.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(version, existingMatch.Version); err != nil {
+			return err
+		}
+
+		return s.replace(repos, actor, existingMatch, req)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Update fields if provided
-	if req.MatchDate != "" {
-		existingMatch.MatchDate = req.MatchDate
+	return s.getUpdated(id)
+}
+
+// Patch applies a JSON merge patch to a match. The patched match is validated like a full update.
+func (s *matchService) Patch(actor policy.Actor, id, version int, patch []byte) (*dto.MatchResponse, error) {
+	if err := policy.CanManageMatches(actor); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		existingMatch, err := repos.Matches.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(version, existingMatch.Version); err != nil {
+			return err
+		}
+
+		var req dto.UpdateMatchRequest
+		if err := utils.ApplyMergePatch(toUpdateMatchRequest(existingMatch), patch, &req); err != nil {
+			return err
+		}
+		if err := utils.ValidationError(utils.ValidateStruct(req), validator.ValidateUpdateMatch(req)); err != nil {
+			return err
+		}
+
+		return s.replace(repos, actor, existingMatch, req)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if req.MatchTime != "" {
-		existingMatch.MatchTime = req.MatchTime
+	return s.getUpdated(id)
+}
+
+// replace overwrites the schedule, teams and status of an existing match with the request and
+// records the change. The goals of a match must stay valid: its teams cannot change once it has
+// goals, and it cannot go back to not started. When the status crosses from not started to
+// started or back, the score is recounted from the goals.
+func (s *matchService) replace(repos *repository.Repositories, actor policy.Actor, existingMatch *models.Match, req dto.UpdateMatchRequest) error {
+	before := toMatchResponse(existingMatch)
+	wasStarted := existingMatch.HasStarted()
+
+	goals, err := repos.Goals.FindByMatchID(existingMatch.ID)
+	if err != nil {
+		return err
+	}
+	if len(goals) > 0 {
+		if req.HomeTeamID != existingMatch.HomeTeamID || req.AwayTeamID != existingMatch.AwayTeamID {
+			return ErrMatchTeamsHaveGoals
+		}
+		if !models.MatchStatus(req.Status).HasStarted() {
+			return ErrMatchStatusHasGoals
+		}
 	}
 
-	if req.HomeTeamID != 0 {
-		// Validate team exists
-		_, err := s.teamRepo.FindByID(req.HomeTeamID)
+	if req.HomeTeamID != existingMatch.HomeTeamID {
+		// Validate team exists and can still play
+		homeTeam, err := repos.Teams.FindByID(req.HomeTeamID)
 		if err != nil {
-			return nil, errors.New("tim home tidak ditemukan")
+			return referenced(err, repository.ErrTeamNotFound, ErrUnknownHomeTeam)
+		}
+		if homeTeam.IsArchived() {
+			return ErrTeamArchived
 		}
-		existingMatch.HomeTeamID = req.HomeTeamID
 	}
 
-	if req.AwayTeamID != 0 {
-		// Validate team exists
-		_, err := s.teamRepo.FindByID(req.AwayTeamID)
+	if req.AwayTeamID != existingMatch.AwayTeamID {
+		// Validate team exists and can still play
+		awayTeam, err := repos.Teams.FindByID(req.AwayTeamID)
 		if err != nil {
-			return nil, errors.New("tim away tidak ditemukan")
+			return referenced(err, repository.ErrTeamNotFound, ErrUnknownAwayTeam)
+		}
+		if awayTeam.IsArchived() {
+			return ErrTeamArchived
 		}
-		existingMatch.AwayTeamID = req.AwayTeamID
 	}
 
-	if req.Status != "" {
-		existingMatch.Status = models.MatchStatus(req.Status)
+	if req.HomeTeamID == req.AwayTeamID {
+		return ErrSameTeams
 	}
 
-	err = s.matchRepo.Update(id, existingMatch)
-	if err != nil {
-		return nil, err
+	existingMatch.MatchDate = req.MatchDate
+	existingMatch.MatchTime = req.MatchTime
+	existingMatch.HomeTeamID = req.HomeTeamID
+	existingMatch.AwayTeamID = req.AwayTeamID
+	existingMatch.Status = models.MatchStatus(req.Status)
+
+	if err := repos.Matches.Update(existingMatch.ID, existingMatch); err != nil {
+		return err
 	}
 
-	// Get updated match
-	updatedMatch, err := s.matchRepo.FindByID(id)
+	// A match that starts gets the score of its goals; one set back to not started has no
+	// score, as it has not been played yet
+	if existingMatch.HasStarted() != wasStarted {
+		if existingMatch.HasStarted() {
+			err = repos.Matches.RecalculateScore(existingMatch.ID)
+		} else {
+			err = repos.Matches.ClearScore(existingMatch.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return recordMatchAudit(repos, actor, existingMatch.ID, before)
+}
+
+// recordMatchAudit records the change of a match from before to its current state
+func recordMatchAudit(repos *repository.Repositories, actor policy.Actor, id int, before *dto.MatchResponse) error {
+	updatedMatch, err := repos.Matches.FindByID(id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return s.mapToResponse(updatedMatch), nil
+	return recordAudit(repos, actor, models.AuditEntityMatch, id, before, toMatchResponse(updatedMatch))
 }
 
-// UpdateResult updates match result with goals
-func (s *matchService) UpdateResult(id int, req dto.UpdateMatchResultRequest) (*dto.MatchResponse, error) {
-	// Get existing match
-	match, err := s.matchRepo.FindByID(id)
+// getUpdated reads a match back after it has been committed
+func (s *matchService) getUpdated(id int) (*dto.MatchResponse, error) {
+	updatedMatch, err := s.matchRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Validate all players and count goals per team
-	homeGoals := 0
-	awayGoals := 0
+	return toMatchResponse(updatedMatch), nil
+}
 
-	for _, goalInput := range req.Goals {
-		player, err := s.playerRepo.FindByID(goalInput.PlayerID)
+// UpdateResult updates match result with goals. Goals and score are written in a
+// single transaction so a failure never leaves them out of sync.
+func (s *matchService) UpdateResult(actor policy.Actor, id, version int, req dto.UpdateMatchResultRequest) (*dto.MatchResponse, error) {
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		// Get existing match
+		match, err := repos.Matches.FindByID(id)
 		if err != nil {
-			return nil, errors.New("pemain dengan ID " + string(rune(goalInput.PlayerID)) + " tidak ditemukan")
+			return err
 		}
-
-		// Check if player belongs to one of the teams in the match
-		if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
-			return nil, errors.New("pemain " + player.Name + " tidak bermain untuk tim yang bertanding")
+		if err := policy.CanSubmitResult(actor, match); err != nil {
+			return err
+		}
+		if err := checkVersion(version, match.Version); err != nil {
+			return err
 		}
 
-		// Count goals per team
-		if player.TeamID == match.HomeTeamID {
-			homeGoals++
-		} else {
-			awayGoals++
+		// Validate all players and count goals per team
+		homeGoals := 0
+		awayGoals := 0
+		scorerTeams := make([]int, len(req.Goals))
+
+		for i, goalInput := range req.Goals {
+			player, err := repos.Players.FindByID(goalInput.PlayerID)
+			if err != nil {
+				return referenced(err, repository.ErrPlayerNotFound, ErrUnknownPlayer.WithDetail(fmt.Sprintf("ID %d", goalInput.PlayerID)))
+			}
+
+			// Check if player belongs to one of the teams in the match
+			if player.TeamID != match.HomeTeamID && player.TeamID != match.AwayTeamID {
+				return ErrPlayerNotInMatch.WithDetail(player.Name)
+			}
+
+			// Count goals per team
+			scorerTeams[i] = player.TeamID
+			if player.TeamID == match.HomeTeamID {
+				homeGoals++
+			} else {
+				awayGoals++
+			}
 		}
-	}
 
-	// Validate goal count matches the scores
-	if homeGoals != req.HomeScore || awayGoals != req.AwayScore {
-		return nil, errors.New("jumlah gol per tim tidak sesuai dengan skor yang diberikan")
-	}
+		// Validate goal count matches the scores
+		if homeGoals != req.HomeScore || awayGoals != req.AwayScore {
+			return ErrGoalsScoreMismatch
+		}
 
-	// Delete existing goals for this match
-	err = s.goalRepo.DeleteByMatchID(id)
-	if err != nil {
-		return nil, err
-	}
+		// Delete existing goals for this match
+		existingGoals, err := repos.Goals.FindByMatchID(id)
+		if err != nil {
+			return err
+		}
+		if err := repos.Goals.DeleteByMatchID(id); err != nil {
+			return err
+		}
+		for _, goal := range existingGoals {
+			if err := recordAudit(repos, actor, models.AuditEntityGoal, goal.ID, toGoalResponse(&goal), nil); err != nil {
+				return err
+			}
+		}
 
-	// Create new goals
-	for _, goalInput := range req.Goals {
-		goal := &models.Goal{
-			MatchID:  id,
-			PlayerID: goalInput.PlayerID,
-			GoalTime: goalInput.GoalTime,
+		// Create new goals
+		for i, goalInput := range req.Goals {
+			goal := &models.Goal{
+				MatchID:  id,
+				PlayerID: goalInput.PlayerID,
+				TeamID:   scorerTeams[i],
+				GoalTime: goalInput.GoalTime,
+			}
+			if err := repos.Goals.Create(goal); err != nil {
+				return err
+			}
+
+			createdGoal, err := repos.Goals.FindByID(goal.ID)
+			if err != nil {
+				return err
+			}
+			if err := recordAudit(repos, actor, models.AuditEntityGoal, goal.ID, nil, toGoalResponse(createdGoal)); err != nil {
+				return err
+			}
 		}
-		err = s.goalRepo.Create(goal)
-		if err != nil {
-			return nil, err
+
+		// Update match result
+		before := toMatchResponse(match)
+		if err := repos.Matches.UpdateResult(id, match.Version, req.HomeScore, req.AwayScore, models.StatusCompleted); err != nil {
+			return err
 		}
-	}
 
-	// Update match result
-	err = s.matchRepo.UpdateResult(id, req.HomeScore, req.AwayScore, models.StatusCompleted)
+		return recordMatchAudit(repos, actor, id, before)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -227,16 +453,61 @@ func (s *matchService) UpdateResult(id int, req dto.UpdateMatchResultRequest) (*
 		return nil, err
 	}
 
-	return s.mapToResponse(updatedMatch), nil
+	return toMatchResponse(updatedMatch), nil
 }
 
-// Delete deletes a match
-func (s *matchService) Delete(id int) error {
-	return s.matchRepo.Delete(id)
+// Delete deletes a match if it still has the version the client read
+func (s *matchService) Delete(actor policy.Actor, id, version int) error {
+	if err := policy.CanManageMatches(actor); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repos *repository.Repositories) error {
+		match, err := repos.Matches.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(version, match.Version); err != nil {
+			return err
+		}
+
+		if err := repos.Matches.Delete(id, match.Version); err != nil {
+			return err
+		}
+
+		return recordAudit(repos, actor, models.AuditEntityMatch, id, toMatchResponse(match), nil)
+	})
 }
 
-// mapToResponse maps match model to response DTO
-func (s *matchService) mapToResponse(match *models.Match) *dto.MatchResponse {
+// toUpdateMatchRequest maps a match to the full update request it would be replaced with
+func toUpdateMatchRequest(match *models.Match) dto.UpdateMatchRequest {
+	// DATE columns are scanned as RFC 3339 timestamps, keep only the date part
+	matchDate := match.MatchDate
+	if len(matchDate) > len("2006-01-02") {
+		matchDate = matchDate[:len("2006-01-02")]
+	}
+
+	return dto.UpdateMatchRequest{
+		MatchDate:  matchDate,
+		MatchTime:  match.MatchTime,
+		HomeTeamID: match.HomeTeamID,
+		AwayTeamID: match.AwayTeamID,
+		Status:     string(match.Status),
+	}
+}
+
+// embedRelations embeds the requested relations in the matches, loading each relation for all
+// matches with a single query
+func (s *matchService) embedRelations(matches []dto.MatchResponse, includes []string) error {
+	if utils.Contains(includes, config.IncludeGoals) {
+		return embedMatchGoals(s.goalRepo, matches)
+	}
+
+	return nil
+}
+
+// toMatchResponse maps match model to response DTO
+func toMatchResponse(match *models.Match) *dto.MatchResponse {
 	response := &dto.MatchResponse{
 		ID:         match.ID,
 		MatchDate:  match.MatchDate,
@@ -246,6 +517,7 @@ func (s *matchService) mapToResponse(match *models.Match) *dto.MatchResponse {
 		HomeScore:  utils.NullInt32ToIntPtr(match.HomeScore),
 		AwayScore:  utils.NullInt32ToIntPtr(match.AwayScore),
 		Status:     string(match.Status),
+		Version:    match.Version,
 		CreatedAt:  utils.FormatDateTime(match.CreatedAt),
 		UpdatedAt:  utils.FormatDateTime(match.UpdatedAt),
 	}
@@ -260,3 +532,37 @@ func (s *matchService) mapToResponse(match *models.Match) *dto.MatchResponse {
 
 	return response
 }
+
+// toTeamMatchResponse maps match model to response DTO from the given team's perspective
+func toTeamMatchResponse(match *models.Match, teamID int) dto.TeamMatchResponse {
+	response := dto.TeamMatchResponse{
+		MatchResponse: *toMatchResponse(match),
+	}
+
+	if match.HomeTeamID == teamID {
+		response.Venue = config.VenueHome
+		response.OpponentID = match.AwayTeamID
+		response.OpponentName = response.AwayTeamName
+		response.GoalsScored = response.HomeScore
+		response.GoalsConceded = response.AwayScore
+	} else {
+		response.Venue = config.VenueAway
+		response.OpponentID = match.HomeTeamID
+		response.OpponentName = response.HomeTeamName
+		response.GoalsScored = response.AwayScore
+		response.GoalsConceded = response.HomeScore
+	}
+
+	if match.Status == models.StatusCompleted && response.GoalsScored != nil && response.GoalsConceded != nil {
+		switch {
+		case *response.GoalsScored > *response.GoalsConceded:
+			response.Result = config.FormWin
+		case *response.GoalsScored < *response.GoalsConceded:
+			response.Result = config.FormLoss
+		default:
+			response.Result = config.FormDraw
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"football-management-api/internal/dto"
+	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
+	"football-management-api/internal/repository"
+)
+
+// fakeMatchRepository records how a match's score was written. Methods the tests do not use
+// panic through the embedded nil interface.
+type fakeMatchRepository struct {
+	repository.MatchRepository
+	match        *models.Match
+	recalculated bool
+	cleared      bool
+}
+
+func (r *fakeMatchRepository) FindByID(id int) (*models.Match, error) {
+	return r.match, nil
+}
+
+func (r *fakeMatchRepository) Update(id int, match *models.Match) error {
+	r.match = match
+	return nil
+}
+
+func (r *fakeMatchRepository) RecalculateScore(id int) error {
+	r.recalculated = true
+	return nil
+}
+
+func (r *fakeMatchRepository) ClearScore(id int) error {
+	r.cleared = true
+	return nil
+}
+
+type fakeGoalRepository struct {
+	repository.GoalRepository
+	goals []models.Goal
+}
+
+func (r *fakeGoalRepository) FindByMatchID(matchID int) ([]models.Goal, error) {
+	return r.goals, nil
+}
+
+type fakeAuditRepository struct {
+	repository.AuditRepository
+}
+
+func (r *fakeAuditRepository) Create(entry *models.AuditLog) error {
+	return nil
+}
+
+func TestMatchReplaceScore(t *testing.T) {
+	score := func(n int32) sql.NullInt32 { return sql.NullInt32{Int32: n, Valid: true} }
+
+	tests := []struct {
+		name             string
+		from             models.MatchStatus
+		to               models.MatchStatus
+		wantRecalculated bool
+		wantCleared      bool
+	}{
+		{"kick-off counts the goals", models.StatusScheduled, models.StatusLive, true, false},
+		{"back to scheduled clears the score", models.StatusLive, models.StatusScheduled, false, true},
+		{"cancelled before it was over clears the score", models.StatusLive, models.StatusCancelled, false, true},
+		{"live to completed keeps the score", models.StatusLive, models.StatusCompleted, false, false},
+		{"rescheduling keeps no score", models.StatusScheduled, models.StatusScheduled, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := &models.Match{ID: 1, HomeTeamID: 1, AwayTeamID: 2, Status: tt.from, Version: 1}
+			if tt.from.HasStarted() {
+				match.HomeScore, match.AwayScore = score(0), score(0)
+			}
+
+			matches := &fakeMatchRepository{match: match}
+			repos := &repository.Repositories{
+				Matches: matches,
+				Goals:   &fakeGoalRepository{},
+				Audit:   &fakeAuditRepository{},
+			}
+			req := dto.UpdateMatchRequest{
+				MatchDate:  "2024-10-01",
+				MatchTime:  "19:00:00",
+				HomeTeamID: 1,
+				AwayTeamID: 2,
+				Status:     string(tt.to),
+			}
+
+			if err := (&matchService{}).replace(repos, policy.System, match, req); err != nil {
+				t.Fatalf("replace() error = %v", err)
+			}
+			if matches.recalculated != tt.wantRecalculated || matches.cleared != tt.wantCleared {
+				t.Errorf("recalculated = %v, cleared = %v, want %v, %v",
+					matches.recalculated, matches.cleared, tt.wantRecalculated, tt.wantCleared)
+			}
+		})
+	}
+}
+
+func TestMatchReplaceWithGoalsKeepsStarted(t *testing.T) {
+	match := &models.Match{ID: 1, HomeTeamID: 1, AwayTeamID: 2, Status: models.StatusLive, Version: 1}
+	matches := &fakeMatchRepository{match: match}
+	repos := &repository.Repositories{
+		Matches: matches,
+		Goals:   &fakeGoalRepository{goals: []models.Goal{{ID: 1, MatchID: 1, TeamID: 1}}},
+		Audit:   &fakeAuditRepository{},
+	}
+	req := dto.UpdateMatchRequest{
+		MatchDate:  "2024-10-01",
+		MatchTime:  "19:00:00",
+		HomeTeamID: 1,
+		AwayTeamID: 2,
+		Status:     string(models.StatusScheduled),
+	}
+
+	if err := (&matchService{}).replace(repos, policy.System, match, req); err != ErrMatchStatusHasGoals {
+		t.Errorf("replace() error = %v, want %v", err, ErrMatchStatusHasGoals)
+	}
+	if matches.cleared {
+		t.Error("score of a match with goals was cleared")
+	}
+}
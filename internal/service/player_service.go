@@ -1,50 +1,55 @@
 package service
 
 import (
-	"errors"
+	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
 	"football-management-api/internal/repository"
 	"football-management-api/internal/utils"
+	"football-management-api/internal/validator"
 )
 
 type PlayerService interface {
-	Create(req dto.CreatePlayerRequest) (*dto.PlayerResponse, error)
-	GetByID(id int) (*dto.PlayerResponse, error)
-	GetAll(page, limit int) ([]dto.PlayerResponse, dto.PaginationMeta, error)
+	Create(actor policy.Actor, req dto.CreatePlayerRequest) (*dto.PlayerResponse, error)
+	GetByID(id int, includes []string) (*dto.PlayerResponse, error)
+	GetAll(query dto.PlayerListQuery, page, limit int) ([]dto.PlayerResponse, dto.PaginationMeta, error)
 	GetByTeamID(teamID int) ([]dto.PlayerResponse, error)
-	Update(id int, req dto.UpdatePlayerRequest) (*dto.PlayerResponse, error)
-	Delete(id int) error
+	GetMatches(id int, query dto.PlayerMatchesQuery, page, limit int) ([]dto.PlayerMatchResponse, dto.PaginationMeta, error)
+	Update(actor policy.Actor, id, version int, req dto.UpdatePlayerRequest) (*dto.PlayerResponse, error)
+	Patch(actor policy.Actor, id, version int, patch []byte) (*dto.PlayerResponse, error)
+	Delete(actor policy.Actor, id, version int) error
 }
 
 type playerService struct {
+	uow        repository.UnitOfWork
 	playerRepo repository.PlayerRepository
 	teamRepo   repository.TeamRepository
+	matchRepo  repository.MatchRepository
+	goalRepo   repository.GoalRepository
 }
 
-func NewPlayerService(playerRepo repository.PlayerRepository, teamRepo repository.TeamRepository) PlayerService {
+func NewPlayerService(
+	uow repository.UnitOfWork,
+	playerRepo repository.PlayerRepository,
+	teamRepo repository.TeamRepository,
+	matchRepo repository.MatchRepository,
+	goalRepo repository.GoalRepository,
+) PlayerService {
 	return &playerService{
+		uow:        uow,
 		playerRepo: playerRepo,
 		teamRepo:   teamRepo,
+		matchRepo:  matchRepo,
+		goalRepo:   goalRepo,
 	}
 }
 
 // Create creates a new player
-func (s *playerService) Create(req dto.CreatePlayerRequest) (*dto.PlayerResponse, error) {
-	// Validate team exists
-	_, err := s.teamRepo.FindByID(req.TeamID)
-	if err != nil {
-		return nil, errors.New("tim tidak ditemukan")
-	}
-
-	// Check if jersey number already exists in the team
-	exists, err := s.playerRepo.CheckJerseyNumberExists(req.TeamID, req.JerseyNumber, 0)
-	if err != nil {
+func (s *playerService) Create(actor policy.Actor, req dto.CreatePlayerRequest) (*dto.PlayerResponse, error) {
+	if err := policy.CanManagePlayers(actor, req.TeamID); err != nil {
 		return nil, err
 	}
-	if exists {
-		return nil, errors.New("nomor punggung sudah digunakan oleh pemain lain di tim ini")
-	}
 
 	player := &models.Player{
 		TeamID:       req.TeamID,
@@ -55,7 +60,36 @@ func (s *playerService) Create(req dto.CreatePlayerRequest) (*dto.PlayerResponse
 		JerseyNumber: req.JerseyNumber,
 	}
 
-	err = s.playerRepo.Create(player)
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		// Validate team exists and still takes players
+		team, err := repos.Teams.FindByID(req.TeamID)
+		if err != nil {
+			return referenced(err, repository.ErrTeamNotFound, ErrUnknownTeam)
+		}
+		if team.IsArchived() {
+			return ErrTeamArchived
+		}
+
+		// Check if jersey number already exists in the team
+		exists, err := repos.Players.CheckJerseyNumberExists(req.TeamID, req.JerseyNumber, 0)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrJerseyNumberTaken
+		}
+
+		if err := repos.Players.Create(player); err != nil {
+			return err
+		}
+
+		createdPlayer, err := repos.Players.FindByID(player.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(repos, actor, models.AuditEntityPlayer, player.ID, nil, toPlayerResponse(createdPlayer))
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -66,40 +100,54 @@ func (s *playerService) Create(req dto.CreatePlayerRequest) (*dto.PlayerResponse
 		return nil, err
 	}
 
-	return s.mapToResponse(createdPlayer), nil
+	return toPlayerResponse(createdPlayer), nil
 }
 
-// GetByID gets a player by ID
-func (s *playerService) GetByID(id int) (*dto.PlayerResponse, error) {
+// GetByID gets a player by ID with the requested relations
+func (s *playerService) GetByID(id int, includes []string) (*dto.PlayerResponse, error) {
 	player, err := s.playerRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapToResponse(player), nil
+	responses := []dto.PlayerResponse{*toPlayerResponse(player)}
+	if err := s.embedRelations(responses, includes); err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
 }
 
-// GetAll gets all players with pagination
-func (s *playerService) GetAll(page, limit int) ([]dto.PlayerResponse, dto.PaginationMeta, error) {
-	offset := utils.CalculateOffset(page, limit)
+// GetAll gets players matching the query with offset or cursor pagination
+func (s *playerService) GetAll(query dto.PlayerListQuery, page, limit int) ([]dto.PlayerResponse, dto.PaginationMeta, error) {
+	filter := models.PlayerFilter{
+		TeamID:    query.TeamID,
+		Position:  models.PlayerPosition(query.Position),
+		HeightMin: query.HeightMin,
+		HeightMax: query.HeightMax,
+	}
 
-	players, total, err := s.playerRepo.FindAll(limit, offset)
+	opts, err := utils.NewListOptions(query.Search, query.Sort, query.Cursor, page, limit)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	players, info, err := s.playerRepo.FindAll(filter, opts)
 	if err != nil {
 		return nil, dto.PaginationMeta{}, err
 	}
 
 	var responses []dto.PlayerResponse
 	for _, player := range players {
-		responses = append(responses, *s.mapToResponse(&player))
+		responses = append(responses, *toPlayerResponse(&player))
 	}
 
-	meta := dto.PaginationMeta{
-		CurrentPage: page,
-		PerPage:     limit,
-		Total:       total,
-		TotalPages:  utils.CalculateTotalPages(total, limit),
+	if err := s.embedRelations(responses, utils.ParseIncludes(query.Include)); err != nil {
+		return nil, dto.PaginationMeta{}, err
 	}
 
+	meta := utils.NewPaginationMeta(info, opts, page)
+
 	return responses, meta, nil
 }
 
@@ -108,7 +156,7 @@ func (s *playerService) GetByTeamID(teamID int) ([]dto.PlayerResponse, error) {
 	// Validate team exists
 	_, err := s.teamRepo.FindByID(teamID)
 	if err != nil {
-		return nil, errors.New("tim tidak ditemukan")
+		return nil, err
 	}
 
 	players, err := s.playerRepo.FindByTeamID(teamID)
@@ -118,82 +166,259 @@ func (s *playerService) GetByTeamID(teamID int) ([]dto.PlayerResponse, error) {
 
 	var responses []dto.PlayerResponse
 	for _, player := range players {
-		responses = append(responses, *s.mapToResponseSimple(&player))
+		responses = append(responses, *toPlayerResponseSimple(&player))
 	}
 
 	return responses, nil
 }
 
-// Update updates a player
-func (s *playerService) Update(id int, req dto.UpdatePlayerRequest) (*dto.PlayerResponse, error) {
-	// Get existing player
-	existingPlayer, err := s.playerRepo.FindByID(id)
+// GetMatches gets the matches of a player's team and the matches the player scored in,
+// with the player's goals in each match
+func (s *playerService) GetMatches(id int, query dto.PlayerMatchesQuery, page, limit int) ([]dto.PlayerMatchResponse, dto.PaginationMeta, error) {
+	player, err := s.playerRepo.FindByID(id)
 	if err != nil {
-		return nil, err
+		return nil, dto.PaginationMeta{}, err
 	}
 
-	// Update fields if provided
-	if req.TeamID != 0 {
-		// Validate new team exists
-		_, err := s.teamRepo.FindByID(req.TeamID)
-		if err != nil {
-			return nil, errors.New("tim tidak ditemukan")
-		}
-		existingPlayer.TeamID = req.TeamID
+	offset := utils.CalculateOffset(page, limit)
+	filter := models.MatchFilter{
+		DateFrom: query.DateFrom,
+		DateTo:   query.DateTo,
 	}
 
-	if req.Name != "" {
-		existingPlayer.Name = req.Name
+	matches, total, err := s.matchRepo.FindByPlayerID(player.ID, player.TeamID, filter, limit, offset)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
 	}
 
-	if req.Height != 0 {
-		existingPlayer.Height = req.Height
+	matchIDs := make([]int, 0, len(matches))
+	for _, match := range matches {
+		matchIDs = append(matchIDs, match.ID)
 	}
 
-	if req.Weight != 0 {
-		existingPlayer.Weight = req.Weight
+	goals, err := s.goalRepo.FindByPlayerIDInMatches(player.ID, matchIDs)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
 	}
 
-	if req.Position != "" {
-		existingPlayer.Position = models.PlayerPosition(req.Position)
+	goalMinutes := make(map[int][]string)
+	for _, goal := range goals {
+		goalMinutes[goal.MatchID] = append(goalMinutes[goal.MatchID], goal.GoalTime)
 	}
 
-	if req.JerseyNumber != 0 {
-		// Check if jersey number is being changed
-		if req.JerseyNumber != existingPlayer.JerseyNumber {
-			// Check if new jersey number already exists in the team
-			exists, err := s.playerRepo.CheckJerseyNumberExists(existingPlayer.TeamID, req.JerseyNumber, id)
-			if err != nil {
-				return nil, err
-			}
-			if exists {
-				return nil, errors.New("nomor punggung sudah digunakan oleh pemain lain di tim ini")
-			}
+	responses := []dto.PlayerMatchResponse{}
+	for _, match := range matches {
+		minutes := goalMinutes[match.ID]
+		if minutes == nil {
+			minutes = []string{}
 		}
-		existingPlayer.JerseyNumber = req.JerseyNumber
+
+		responses = append(responses, dto.PlayerMatchResponse{
+			MatchResponse: *toMatchResponse(&match),
+			GoalsScored:   len(minutes),
+			GoalMinutes:   minutes,
+		})
+	}
+
+	meta := dto.PaginationMeta{
+		CurrentPage: page,
+		PerPage:     limit,
+		Total:       total,
+		TotalPages:  utils.CalculateTotalPages(total, limit),
+	}
+
+	return responses, meta, nil
+}
+
+// Update replaces a player if it still has the version the client read
+func (s *playerService) Update(actor policy.Actor, id, version int, req dto.UpdatePlayerRequest) (*dto.PlayerResponse, error) {
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		existingPlayer, err := repos.Players.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := policy.CanManagePlayers(actor, existingPlayer.TeamID); err != nil {
+			return err
+		}
+		if err := checkVersion(version, existingPlayer.Version); err != nil {
+			return err
+		}
+
+		return s.replace(repos, actor, existingPlayer, req)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.playerRepo.Update(id, existingPlayer)
+	return s.getUpdated(id)
+}
+
+// Patch applies a JSON merge patch to a player. The patched player is validated like a full update.
+func (s *playerService) Patch(actor policy.Actor, id, version int, patch []byte) (*dto.PlayerResponse, error) {
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		existingPlayer, err := repos.Players.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := policy.CanManagePlayers(actor, existingPlayer.TeamID); err != nil {
+			return err
+		}
+		if err := checkVersion(version, existingPlayer.Version); err != nil {
+			return err
+		}
+
+		var req dto.UpdatePlayerRequest
+		if err := utils.ApplyMergePatch(toUpdatePlayerRequest(existingPlayer), patch, &req); err != nil {
+			return err
+		}
+		if err := utils.ValidationError(utils.ValidateStruct(req), validator.ValidateUpdatePlayer(req)); err != nil {
+			return err
+		}
+
+		return s.replace(repos, actor, existingPlayer, req)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Get updated player
+	return s.getUpdated(id)
+}
+
+// replace overwrites every field of an existing player with the request and records the change
+func (s *playerService) replace(repos *repository.Repositories, actor policy.Actor, existingPlayer *models.Player, req dto.UpdatePlayerRequest) error {
+	before := toPlayerResponse(existingPlayer)
+
+	if req.TeamID != existingPlayer.TeamID {
+		// A transfer must be allowed in the new team as well
+		if err := policy.CanManagePlayers(actor, req.TeamID); err != nil {
+			return err
+		}
+
+		// Validate new team exists and still takes players
+		team, err := repos.Teams.FindByID(req.TeamID)
+		if err != nil {
+			return referenced(err, repository.ErrTeamNotFound, ErrUnknownTeam)
+		}
+		if team.IsArchived() {
+			return ErrTeamArchived
+		}
+	}
+
+	existingPlayer.TeamID = req.TeamID
+	existingPlayer.Name = req.Name
+	existingPlayer.Height = req.Height
+	existingPlayer.Weight = req.Weight
+	existingPlayer.Position = models.PlayerPosition(req.Position)
+	existingPlayer.JerseyNumber = req.JerseyNumber
+
+	// Check the jersey number is still free in the (possibly new) team
+	exists, err := repos.Players.CheckJerseyNumberExists(existingPlayer.TeamID, existingPlayer.JerseyNumber, existingPlayer.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrJerseyNumberTaken
+	}
+
+	if err := repos.Players.Update(existingPlayer.ID, existingPlayer); err != nil {
+		return err
+	}
+
+	updatedPlayer, err := repos.Players.FindByID(existingPlayer.ID)
+	if err != nil {
+		return err
+	}
+
+	return recordAudit(repos, actor, models.AuditEntityPlayer, existingPlayer.ID, before, toPlayerResponse(updatedPlayer))
+}
+
+// getUpdated reads a player back after it has been committed
+func (s *playerService) getUpdated(id int) (*dto.PlayerResponse, error) {
 	updatedPlayer, err := s.playerRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapToResponse(updatedPlayer), nil
+	return toPlayerResponse(updatedPlayer), nil
 }
 
-// Delete deletes a player
-func (s *playerService) Delete(id int) error {
-	return s.playerRepo.Delete(id)
+// Delete deletes a player if it still has the version the client read. The player's goals
+// stay active as match history and keep naming the player.
+func (s *playerService) Delete(actor policy.Actor, id, version int) error {
+	return s.uow.Do(func(repos *repository.Repositories) error {
+		player, err := repos.Players.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := policy.CanManagePlayers(actor, player.TeamID); err != nil {
+			return err
+		}
+		if err := checkVersion(version, player.Version); err != nil {
+			return err
+		}
+
+		if err := repos.Players.Delete(id, player.Version); err != nil {
+			return err
+		}
+
+		return recordAudit(repos, actor, models.AuditEntityPlayer, id, toPlayerResponse(player), nil)
+	})
+}
+
+// toUpdatePlayerRequest maps a player to the full update request it would be replaced with
+func toUpdatePlayerRequest(player *models.Player) dto.UpdatePlayerRequest {
+	return dto.UpdatePlayerRequest{
+		TeamID:       player.TeamID,
+		Name:         player.Name,
+		Height:       player.Height,
+		Weight:       player.Weight,
+		Position:     string(player.Position),
+		JerseyNumber: player.JerseyNumber,
+	}
+}
+
+// embedRelations embeds the requested relations in the players, loading each relation for all
+// players with a single query
+func (s *playerService) embedRelations(players []dto.PlayerResponse, includes []string) error {
+	if len(players) == 0 || len(includes) == 0 {
+		return nil
+	}
+
+	if utils.Contains(includes, config.IncludeTeam) {
+		teamIDs := make([]int, len(players))
+		for i, player := range players {
+			teamIDs[i] = player.TeamID
+		}
+
+		teams, err := teamsByID(s.teamRepo, teamIDs)
+		if err != nil {
+			return err
+		}
+		for i := range players {
+			players[i].Team = teams[players[i].TeamID]
+		}
+	}
+
+	if utils.Contains(includes, config.IncludeGoals) {
+		playerIDs := make([]int, len(players))
+		for i, player := range players {
+			playerIDs[i] = player.ID
+		}
+
+		goals, err := goalsByPlayer(s.goalRepo, playerIDs)
+		if err != nil {
+			return err
+		}
+		for i := range players {
+			players[i].Goals = goals[players[i].ID]
+		}
+	}
+
+	return nil
 }
 
-// mapToResponse maps player model to response DTO with team info
-func (s *playerService) mapToResponse(player *models.Player) *dto.PlayerResponse {
+// toPlayerResponse maps player model to response DTO with team info
+func toPlayerResponse(player *models.Player) *dto.PlayerResponse {
 	response := &dto.PlayerResponse{
 		ID:           player.ID,
 		TeamID:       player.TeamID,
@@ -202,6 +427,7 @@ func (s *playerService) mapToResponse(player *models.Player) *dto.PlayerResponse
 		Weight:       player.Weight,
 		Position:     string(player.Position),
 		JerseyNumber: player.JerseyNumber,
+		Version:      player.Version,
 		CreatedAt:    utils.FormatDateTime(player.CreatedAt),
 		UpdatedAt:    utils.FormatDateTime(player.UpdatedAt),
 	}
@@ -213,8 +439,8 @@ func (s *playerService) mapToResponse(player *models.Player) *dto.PlayerResponse
 	return response
 }
 
-// mapToResponseSimple maps player model to response DTO without team info
-func (s *playerService) mapToResponseSimple(player *models.Player) *dto.PlayerResponse {
+// toPlayerResponseSimple maps player model to response DTO without team info
+func toPlayerResponseSimple(player *models.Player) *dto.PlayerResponse {
 	return &dto.PlayerResponse{
 		ID:           player.ID,
 		TeamID:       player.TeamID,
@@ -1,9 +1,18 @@
 package service
 
 import (
-	"errors"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
 	"football-management-api/internal/models"
 	"football-management-api/internal/repository"
+	"football-management-api/internal/utils"
+)
+
+// Errors returned for reports that cannot be built
+var (
+	ErrMatchNotCompleted         = apperror.StateTransition("MATCH_NOT_COMPLETED", "laporan hanya tersedia untuk pertandingan yang sudah selesai")
+	ErrInvalidCleanSheetPosition = apperror.Validation("INVALID_POSITION", "posisi tidak valid. Pilihan: Penjaga Gawang, Bertahan")
+	ErrSameHeadToHeadTeams       = apperror.Validation(ErrSameTeams.Code, "team_a dan team_b tidak boleh sama")
 )
 
 type ReportService interface {
@@ -11,6 +20,10 @@ type ReportService interface {
 	GetTeamStatistics(teamID int) (*models.TeamStatistics, error)
 	GetPlayerStatistics(playerID int) (*models.PlayerStatistics, error)
 	GetTopScorers(limit int) ([]models.PlayerStatistics, error)
+	GetHeadToHead(teamAID, teamBID int) (*models.HeadToHeadReport, error)
+	GetTeamForm(teamID, last int) (*models.TeamForm, error)
+	GetCleanSheets(position string, limit int) ([]models.CleanSheetStatistics, error)
+	DataVersion() (*models.DataVersion, error)
 }
 
 type reportService struct {
@@ -39,36 +52,126 @@ func (s *reportService) GetMatchReport(matchID int) (*models.MatchReport, error)
 	// Validate match exists and is completed
 	match, err := s.matchRepo.FindByID(matchID)
 	if err != nil {
-		return nil, errors.New("pertandingan tidak ditemukan")
+		return nil, err
 	}
 
 	if match.Status != models.StatusCompleted {
-		return nil, errors.New("laporan hanya tersedia untuk pertandingan yang sudah selesai")
+		return nil, ErrMatchNotCompleted
 	}
 
 	return s.reportRepo.GetMatchReport(matchID)
 }
 
-// GetTeamStatistics gets team statistics
+// GetTeamStatistics gets team statistics with home and away records
 func (s *reportService) GetTeamStatistics(teamID int) (*models.TeamStatistics, error) {
 	// Validate team exists
 	_, err := s.teamRepo.FindByID(teamID)
 	if err != nil {
-		return nil, errors.New("tim tidak ditemukan")
+		return nil, err
+	}
+
+	stats, err := s.reportRepo.GetTeamStatistics(teamID)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.reportRepo.GetTeamStatistics(teamID)
+	stats.Home.Points = recordPoints(stats.Home)
+	stats.Away.Points = recordPoints(stats.Away)
+
+	stats.TotalMatches = stats.Home.Played + stats.Away.Played
+	stats.TotalWins = stats.Home.Wins + stats.Away.Wins
+	stats.TotalDraws = stats.Home.Draws + stats.Away.Draws
+	stats.TotalLosses = stats.Home.Losses + stats.Away.Losses
+	stats.GoalsScored = stats.Home.GoalsScored + stats.Away.GoalsScored
+	stats.GoalsConceded = stats.Home.GoalsConceded + stats.Away.GoalsConceded
+	stats.TotalPoints = stats.Home.Points + stats.Away.Points
+
+	advantage, err := s.reportRepo.GetLeagueHomeAdvantage()
+	if err != nil {
+		return nil, err
+	}
+
+	total := advantage.TotalMatches
+	advantage.HomeWinPercentage = utils.Percentage(advantage.HomeWins, total)
+	advantage.AwayWinPercentage = utils.Percentage(advantage.AwayWins, total)
+	advantage.DrawPercentage = utils.Percentage(advantage.Draws, total)
+	advantage.AvgHomeGoals = utils.Average(advantage.HomeGoals, total)
+	advantage.AvgAwayGoals = utils.Average(advantage.AwayGoals, total)
+	advantage.HomePointsPerMatch = utils.Average(advantage.HomeWins*config.PointsWin+advantage.Draws*config.PointsDraw, total)
+	advantage.AwayPointsPerMatch = utils.Average(advantage.AwayWins*config.PointsWin+advantage.Draws*config.PointsDraw, total)
+	stats.LeagueHomeAdvantage = *advantage
+
+	return stats, nil
 }
 
-// GetPlayerStatistics gets player goal statistics
+// recordPoints calculates league points for a record
+func recordPoints(record models.TeamRecord) int {
+	return record.Wins*config.PointsWin + record.Draws*config.PointsDraw + record.Losses*config.PointsLoss
+}
+
+// GetPlayerStatistics gets player goal statistics, plus clean sheets for goalkeepers and defenders
 func (s *reportService) GetPlayerStatistics(playerID int) (*models.PlayerStatistics, error) {
 	// Validate player exists
-	_, err := s.playerRepo.FindByID(playerID)
+	player, err := s.playerRepo.FindByID(playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.reportRepo.GetPlayerStatistics(playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if utils.Contains(config.DefensivePositions(), string(player.Position)) {
+		defensive, err := s.reportRepo.GetPlayerDefensiveStatistics(playerID)
+		if err != nil {
+			return nil, err
+		}
+		calculateDefensiveRates(defensive)
+		stats.Defensive = defensive
+	}
+
+	return stats, nil
+}
+
+// GetCleanSheets gets the clean sheet leaderboard, optionally for a single defensive position
+func (s *reportService) GetCleanSheets(position string, limit int) ([]models.CleanSheetStatistics, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	positions := config.DefensivePositions()
+	if position != "" {
+		if !utils.Contains(positions, position) {
+			return nil, ErrInvalidCleanSheetPosition
+		}
+		positions = []string{position}
+	}
+
+	leaders, err := s.reportRepo.GetCleanSheetLeaders(positions, limit)
 	if err != nil {
-		return nil, errors.New("pemain tidak ditemukan")
+		return nil, err
+	}
+
+	for i := range leaders {
+		calculateDefensiveRates(&leaders[i].DefensiveStatistics)
 	}
 
-	return s.reportRepo.GetPlayerStatistics(playerID)
+	return leaders, nil
+}
+
+// DataVersion gets the marker of the latest change that can affect any report
+func (s *reportService) DataVersion() (*models.DataVersion, error) {
+	return s.reportRepo.GetDataVersion()
+}
+
+// calculateDefensiveRates fills the per-match rates of defensive statistics
+func calculateDefensiveRates(stats *models.DefensiveStatistics) {
+	stats.CleanSheetPercentage = utils.Percentage(stats.CleanSheets, stats.MatchesPlayed)
+	stats.GoalsConcededPerMatch = utils.Average(stats.GoalsConceded, stats.MatchesPlayed)
 }
 
 // GetTopScorers gets top scorers
@@ -82,3 +185,277 @@ func (s *reportService) GetTopScorers(limit int) ([]models.PlayerStatistics, err
 
 	return s.reportRepo.GetTopScorers(limit)
 }
+
+// GetHeadToHead gets all past meetings and aggregated records between two teams
+func (s *reportService) GetHeadToHead(teamAID, teamBID int) (*models.HeadToHeadReport, error) {
+	if teamAID == teamBID {
+		return nil, ErrSameHeadToHeadTeams
+	}
+
+	teamA, err := s.teamRepo.FindByID(teamAID)
+	if err != nil {
+		return nil, referenced(err, repository.ErrTeamNotFound, repository.ErrTeamNotFound.WithDetail("team_a"))
+	}
+
+	teamB, err := s.teamRepo.FindByID(teamBID)
+	if err != nil {
+		return nil, referenced(err, repository.ErrTeamNotFound, repository.ErrTeamNotFound.WithDetail("team_b"))
+	}
+
+	completed := models.MatchFilter{Status: models.StatusCompleted}
+	matches, _, err := s.matchRepo.FindByTeamID(teamAID, completed, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.HeadToHeadReport{
+		TeamA:    toTeamInfo(teamA),
+		TeamB:    toTeamInfo(teamB),
+		Meetings: []models.HeadToHeadMatch{},
+	}
+
+	var biggestMarginA, biggestMarginB int
+	for _, match := range matches {
+		if !match.HomeScore.Valid || !match.AwayScore.Valid {
+			continue
+		}
+		if match.HomeTeamID != teamBID && match.AwayTeamID != teamBID {
+			continue
+		}
+
+		meeting := toHeadToHeadMatch(&match)
+		report.Meetings = append(report.Meetings, meeting)
+		report.TotalMatches++
+
+		homeScore := meeting.FinalScore.Home
+		awayScore := meeting.FinalScore.Away
+
+		if match.HomeTeamID == teamAID {
+			addToHeadToHeadRecord(&report.TeamARecord, true, homeScore, awayScore)
+			addToHeadToHeadRecord(&report.TeamBRecord, false, awayScore, homeScore)
+		} else {
+			addToHeadToHeadRecord(&report.TeamARecord, false, awayScore, homeScore)
+			addToHeadToHeadRecord(&report.TeamBRecord, true, homeScore, awayScore)
+		}
+
+		// Meetings are ordered newest first, so a strictly larger margin keeps
+		// the most recent win when margins are equal
+		margin := homeScore - awayScore
+		winnerID := match.HomeTeamID
+		if margin < 0 {
+			margin = -margin
+			winnerID = match.AwayTeamID
+		}
+		if margin == 0 {
+			continue
+		}
+		if winnerID == teamAID && margin > biggestMarginA {
+			biggestMarginA = margin
+			win := meeting
+			report.BiggestWinTeamA = &win
+		}
+		if winnerID == teamBID && margin > biggestMarginB {
+			biggestMarginB = margin
+			win := meeting
+			report.BiggestWinTeamB = &win
+		}
+	}
+
+	topScorers, err := s.reportRepo.GetHeadToHeadTopScorers(teamAID, teamBID, 5)
+	if err != nil {
+		return nil, err
+	}
+	if topScorers == nil {
+		topScorers = []models.TopScorerInfo{}
+	}
+	report.TopScorers = topScorers
+
+	return report, nil
+}
+
+// addToHeadToHeadRecord adds a single result to a team's overall and home/away records
+func addToHeadToHeadRecord(record *models.HeadToHeadRecord, isHome bool, goalsFor, goalsAgainst int) {
+	addToTeamRecord(&record.TeamRecord, goalsFor, goalsAgainst)
+	if isHome {
+		addToTeamRecord(&record.Home, goalsFor, goalsAgainst)
+	} else {
+		addToTeamRecord(&record.Away, goalsFor, goalsAgainst)
+	}
+}
+
+// addToTeamRecord adds a single result to a team record
+func addToTeamRecord(record *models.TeamRecord, goalsFor, goalsAgainst int) {
+	record.Played++
+	record.GoalsScored += goalsFor
+	record.GoalsConceded += goalsAgainst
+
+	switch {
+	case goalsFor > goalsAgainst:
+		record.Wins++
+		record.Points += config.PointsWin
+	case goalsFor < goalsAgainst:
+		record.Losses++
+		record.Points += config.PointsLoss
+	default:
+		record.Draws++
+		record.Points += config.PointsDraw
+	}
+}
+
+// toHeadToHeadMatch maps a completed match to a head-to-head meeting
+func toHeadToHeadMatch(match *models.Match) models.HeadToHeadMatch {
+	meeting := models.HeadToHeadMatch{
+		MatchID:    match.ID,
+		MatchDate:  match.MatchDate,
+		MatchTime:  match.MatchTime,
+		HomeTeamID: match.HomeTeamID,
+		AwayTeamID: match.AwayTeamID,
+		FinalScore: models.ScoreInfo{
+			Home: int(match.HomeScore.Int32),
+			Away: int(match.AwayScore.Int32),
+		},
+		MatchResult: matchResult(int(match.HomeScore.Int32), int(match.AwayScore.Int32)),
+	}
+
+	if match.HomeTeam != nil {
+		meeting.HomeTeamName = match.HomeTeam.Name
+	}
+	if match.AwayTeam != nil {
+		meeting.AwayTeamName = match.AwayTeam.Name
+	}
+
+	return meeting
+}
+
+// matchResult describes a final score from the home team's perspective
+func matchResult(homeScore, awayScore int) string {
+	if homeScore > awayScore {
+		return config.MatchResultHomeWin
+	}
+	if awayScore > homeScore {
+		return config.MatchResultAwayWin
+	}
+	return config.MatchResultDraw
+}
+
+// toTeamInfo maps a team model to the basic team info used in reports
+func toTeamInfo(team *models.Team) models.TeamInfo {
+	return models.TeamInfo{
+		ID:       team.ID,
+		Name:     team.Name,
+		LogoURL:  utils.NullStringToString(team.LogoURL),
+		HomeCity: team.HomeCity,
+	}
+}
+
+// GetTeamForm gets the last results of a team and its current and longest streaks
+func (s *reportService) GetTeamForm(teamID, last int) (*models.TeamForm, error) {
+	if last <= 0 {
+		last = 5
+	}
+	if last > 50 {
+		last = 50
+	}
+
+	team, err := s.teamRepo.FindByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := models.MatchFilter{Status: models.StatusCompleted}
+	matches, _, err := s.matchRepo.FindByTeamID(teamID, completed, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Matches are ordered newest first
+	var results []models.FormResult
+	for _, match := range matches {
+		if !match.HomeScore.Valid || !match.AwayScore.Valid {
+			continue
+		}
+		results = append(results, toFormResult(&match, teamID))
+	}
+
+	form := &models.TeamForm{
+		TeamID:   team.ID,
+		TeamName: team.Name,
+		Results:  []models.FormResult{},
+	}
+
+	for i := len(results) - 1; i >= 0; i-- {
+		addToStreaks(&form.Streaks, results[i])
+	}
+
+	if len(results) > last {
+		results = results[:last]
+	}
+	for _, result := range results {
+		form.Form += result.Result
+	}
+	form.Results = append(form.Results, results...)
+
+	return form, nil
+}
+
+// toFormResult maps a completed match to a result from the given team's perspective
+func toFormResult(match *models.Match, teamID int) models.FormResult {
+	result := models.FormResult{
+		MatchID:   match.ID,
+		MatchDate: match.MatchDate,
+		MatchTime: match.MatchTime,
+	}
+
+	homeScore := int(match.HomeScore.Int32)
+	awayScore := int(match.AwayScore.Int32)
+
+	if match.HomeTeamID == teamID {
+		result.Venue = config.VenueHome
+		result.OpponentID = match.AwayTeamID
+		result.GoalsScored = homeScore
+		result.GoalsConceded = awayScore
+		if match.AwayTeam != nil {
+			result.OpponentName = match.AwayTeam.Name
+		}
+	} else {
+		result.Venue = config.VenueAway
+		result.OpponentID = match.HomeTeamID
+		result.GoalsScored = awayScore
+		result.GoalsConceded = homeScore
+		if match.HomeTeam != nil {
+			result.OpponentName = match.HomeTeam.Name
+		}
+	}
+
+	switch {
+	case result.GoalsScored > result.GoalsConceded:
+		result.Result = config.FormWin
+	case result.GoalsScored < result.GoalsConceded:
+		result.Result = config.FormLoss
+	default:
+		result.Result = config.FormDraw
+	}
+
+	return result
+}
+
+// addToStreaks extends or resets each streak with the next result in chronological order
+func addToStreaks(streaks *models.FormStreaks, result models.FormResult) {
+	extendStreak(&streaks.Winning, result.Result == config.FormWin)
+	extendStreak(&streaks.Unbeaten, result.Result != config.FormLoss)
+	extendStreak(&streaks.Losing, result.Result == config.FormLoss)
+	extendStreak(&streaks.Scoring, result.GoalsScored > 0)
+}
+
+// extendStreak increments the current streak when it continues, otherwise resets it
+func extendStreak(streak *models.Streak, continues bool) {
+	if !continues {
+		streak.Current = 0
+		return
+	}
+
+	streak.Current++
+	if streak.Current > streak.Longest {
+		streak.Longest = streak.Current
+	}
+}
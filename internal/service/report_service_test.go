@@ -0,0 +1,132 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"football-management-api/internal/config"
+	"football-management-api/internal/models"
+)
+
+func TestToFormResult(t *testing.T) {
+	match := &models.Match{
+		ID:         7,
+		HomeTeamID: 1,
+		AwayTeamID: 2,
+		HomeScore:  sql.NullInt32{Int32: 2, Valid: true},
+		AwayScore:  sql.NullInt32{Int32: 1, Valid: true},
+		HomeTeam:   &models.Team{ID: 1, Name: "Persija"},
+		AwayTeam:   &models.Team{ID: 2, Name: "Persib"},
+	}
+
+	tests := []struct {
+		name         string
+		teamID       int
+		wantVenue    string
+		wantOpponent string
+		wantScored   int
+		wantConceded int
+		wantResult   string
+	}{
+		{"home team wins", 1, config.VenueHome, "Persib", 2, 1, config.FormWin},
+		{"away team loses", 2, config.VenueAway, "Persija", 1, 2, config.FormLoss},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := toFormResult(match, tt.teamID)
+			if result.MatchID != match.ID || result.Venue != tt.wantVenue || result.OpponentName != tt.wantOpponent ||
+				result.GoalsScored != tt.wantScored || result.GoalsConceded != tt.wantConceded || result.Result != tt.wantResult {
+				t.Errorf("toFormResult() = %+v", result)
+			}
+		})
+	}
+}
+
+func TestToFormResultDraw(t *testing.T) {
+	match := &models.Match{
+		HomeTeamID: 1,
+		AwayTeamID: 2,
+		HomeScore:  sql.NullInt32{Int32: 1, Valid: true},
+		AwayScore:  sql.NullInt32{Int32: 1, Valid: true},
+	}
+
+	result := toFormResult(match, 2)
+	if result.Result != config.FormDraw {
+		t.Errorf("Result = %q, want %q", result.Result, config.FormDraw)
+	}
+	if result.OpponentID != 1 || result.OpponentName != "" {
+		t.Errorf("opponent = %d %q, want 1 without a name", result.OpponentID, result.OpponentName)
+	}
+}
+
+func TestAddToStreaks(t *testing.T) {
+	result := func(outcome string, scored int) models.FormResult {
+		return models.FormResult{Result: outcome, GoalsScored: scored}
+	}
+
+	tests := []struct {
+		name    string
+		results []models.FormResult
+		want    models.FormStreaks
+	}{
+		{
+			name: "no matches",
+			want: models.FormStreaks{},
+		},
+		{
+			name: "current winning run",
+			results: []models.FormResult{
+				result(config.FormWin, 1),
+				result(config.FormWin, 2),
+				result(config.FormWin, 3),
+			},
+			want: models.FormStreaks{
+				Winning:  models.Streak{Current: 3, Longest: 3},
+				Unbeaten: models.Streak{Current: 3, Longest: 3},
+				Scoring:  models.Streak{Current: 3, Longest: 3},
+			},
+		},
+		{
+			name: "loss resets winning and unbeaten runs",
+			results: []models.FormResult{
+				result(config.FormWin, 2),
+				result(config.FormWin, 1),
+				result(config.FormDraw, 0),
+				result(config.FormLoss, 1),
+				result(config.FormWin, 3),
+			},
+			want: models.FormStreaks{
+				Winning:  models.Streak{Current: 1, Longest: 2},
+				Unbeaten: models.Streak{Current: 1, Longest: 3},
+				Losing:   models.Streak{Current: 0, Longest: 1},
+				Scoring:  models.Streak{Current: 2, Longest: 2},
+			},
+		},
+		{
+			name: "current losing run",
+			results: []models.FormResult{
+				result(config.FormDraw, 1),
+				result(config.FormLoss, 0),
+				result(config.FormLoss, 0),
+			},
+			want: models.FormStreaks{
+				Unbeaten: models.Streak{Current: 0, Longest: 1},
+				Losing:   models.Streak{Current: 2, Longest: 2},
+				Scoring:  models.Streak{Current: 0, Longest: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var streaks models.FormStreaks
+			for _, r := range tt.results {
+				addToStreaks(&streaks, r)
+			}
+			if streaks != tt.want {
+				t.Errorf("streaks = %+v, want %+v", streaks, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"football-management-api/internal/config"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/models"
+	"football-management-api/internal/repository"
+	"football-management-api/internal/utils"
+	"strings"
+)
+
+type SearchService interface {
+	Search(query dto.SearchQuery) ([]models.SearchResult, error)
+}
+
+type searchService struct {
+	searchRepo repository.SearchRepository
+}
+
+func NewSearchService(searchRepo repository.SearchRepository) SearchService {
+	return &searchService{searchRepo: searchRepo}
+}
+
+// Search searches teams, players and matches and returns mixed results ranked by relevance
+func (s *searchService) Search(query dto.SearchQuery) ([]models.SearchResult, error) {
+	types := config.ValidSearchTypes()
+	if query.Type != "" {
+		types = nil
+		for _, t := range strings.Split(query.Type, ",") {
+			types = append(types, strings.TrimSpace(t))
+		}
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = config.SearchDefaultLimit
+	}
+
+	results, err := s.searchRepo.Search(strings.TrimSpace(query.Q), types, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Score = utils.Round(results[i].Score, 3)
+	}
+
+	return results, nil
+}
@@ -1,39 +1,66 @@
 package service
 
 import (
-	"errors"
+	"fmt"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
 	"football-management-api/internal/repository"
 	"football-management-api/internal/utils"
+	"football-management-api/internal/validator"
 )
 
+// ErrHasDependents is returned when a team delete policy is blocked by the team's active
+// players or matches
+var ErrHasDependents = apperror.Conflict("TEAM_HAS_DEPENDENTS", "tim masih memiliki data aktif")
+
+// ErrTeamArchived is returned when an archived team is given a new player or match
+var ErrTeamArchived = apperror.StateTransition("TEAM_ARCHIVED", "tim sudah diarsipkan dan tidak dapat menerima pemain atau pertandingan baru")
+
+// ErrInvalidDeletePolicy is returned for an unknown team delete policy
+var ErrInvalidDeletePolicy = apperror.Validation("INVALID_DELETE_POLICY", "policy harus salah satu dari: restrict, cascade, archive")
+
 type TeamService interface {
-	Create(req dto.CreateTeamRequest) (*dto.TeamResponse, error)
-	GetByID(id int) (*dto.TeamResponse, error)
-	GetAll(page, limit int) ([]dto.TeamResponse, dto.PaginationMeta, error)
-	Update(id int, req dto.UpdateTeamRequest) (*dto.TeamResponse, error)
-	Delete(id int) error
+	Create(actor policy.Actor, req dto.CreateTeamRequest) (*dto.TeamResponse, error)
+	GetByID(id int, includes []string) (*dto.TeamResponse, error)
+	GetAll(query dto.TeamListQuery, page, limit int) ([]dto.TeamResponse, dto.PaginationMeta, error)
+	Update(actor policy.Actor, id, version int, req dto.UpdateTeamRequest) (*dto.TeamResponse, error)
+	Patch(actor policy.Actor, id, version int, patch []byte) (*dto.TeamResponse, error)
+	Delete(actor policy.Actor, id, version int, deletePolicy string) (*dto.TeamDeletePreview, error)
+	PreviewDelete(actor policy.Actor, id int, deletePolicy string) (*dto.TeamDeletePreview, error)
 }
 
 type teamService struct {
-	teamRepo repository.TeamRepository
+	uow        repository.UnitOfWork
+	teamRepo   repository.TeamRepository
+	playerRepo repository.PlayerRepository
+	matchRepo  repository.MatchRepository
+	goalRepo   repository.GoalRepository
 }
 
-func NewTeamService(teamRepo repository.TeamRepository) TeamService {
-	return &teamService{teamRepo: teamRepo}
+func NewTeamService(
+	uow repository.UnitOfWork,
+	teamRepo repository.TeamRepository,
+	playerRepo repository.PlayerRepository,
+	matchRepo repository.MatchRepository,
+	goalRepo repository.GoalRepository,
+) TeamService {
+	return &teamService{
+		uow:        uow,
+		teamRepo:   teamRepo,
+		playerRepo: playerRepo,
+		matchRepo:  matchRepo,
+		goalRepo:   goalRepo,
+	}
 }
 
 // Create creates a new team
-func (s *teamService) Create(req dto.CreateTeamRequest) (*dto.TeamResponse, error) {
-	// Check if team name already exists
-	existingTeam, err := s.teamRepo.FindByName(req.Name)
-	if err != nil {
+func (s *teamService) Create(actor policy.Actor, req dto.CreateTeamRequest) (*dto.TeamResponse, error) {
+	if err := policy.CanManageTeams(actor); err != nil {
 		return nil, err
 	}
-	if existingTeam != nil {
-		return nil, errors.New("nama tim sudah digunakan")
-	}
 
 	team := &models.Team{
 		Name:        req.Name,
@@ -43,103 +70,383 @@ func (s *teamService) Create(req dto.CreateTeamRequest) (*dto.TeamResponse, erro
 		HomeCity:    req.HomeCity,
 	}
 
-	err = s.teamRepo.Create(team)
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		// Check if team name already exists
+		existingTeam, err := repos.Teams.FindByName(req.Name)
+		if err != nil {
+			return err
+		}
+		if existingTeam != nil {
+			return ErrTeamNameTaken
+		}
+
+		if err := repos.Teams.Create(team); err != nil {
+			return err
+		}
+
+		createdTeam, err := repos.Teams.FindByID(team.ID)
+		if err != nil {
+			return err
+		}
+		*team = *createdTeam
+
+		return recordAudit(repos, actor, models.AuditEntityTeam, team.ID, nil, toTeamResponse(team))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapToResponse(team), nil
+	return toTeamResponse(team), nil
 }
 
-// GetByID gets a team by ID
-func (s *teamService) GetByID(id int) (*dto.TeamResponse, error) {
+// GetByID gets a team by ID with the requested relations
+func (s *teamService) GetByID(id int, includes []string) (*dto.TeamResponse, error) {
 	team, err := s.teamRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapToResponse(team), nil
+	responses := []dto.TeamResponse{*toTeamResponse(team)}
+	if err := s.embedRelations(responses, includes); err != nil {
+		return nil, err
+	}
+
+	return &responses[0], nil
 }
 
-// GetAll gets all teams with pagination
-func (s *teamService) GetAll(page, limit int) ([]dto.TeamResponse, dto.PaginationMeta, error) {
-	offset := utils.CalculateOffset(page, limit)
+// GetAll gets teams matching the query with offset or cursor pagination
+func (s *teamService) GetAll(query dto.TeamListQuery, page, limit int) ([]dto.TeamResponse, dto.PaginationMeta, error) {
+	filter := models.TeamFilter{
+		City:        query.City,
+		FoundedFrom: query.FoundedFrom,
+		FoundedTo:   query.FoundedTo,
+		Archived:    query.Archived,
+	}
 
-	teams, total, err := s.teamRepo.FindAll(limit, offset)
+	opts, err := utils.NewListOptions(query.Search, query.Sort, query.Cursor, page, limit)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	teams, info, err := s.teamRepo.FindAll(filter, opts)
 	if err != nil {
 		return nil, dto.PaginationMeta{}, err
 	}
 
 	var responses []dto.TeamResponse
 	for _, team := range teams {
-		responses = append(responses, *s.mapToResponse(&team))
+		responses = append(responses, *toTeamResponse(&team))
 	}
 
-	meta := dto.PaginationMeta{
-		CurrentPage: page,
-		PerPage:     limit,
-		Total:       total,
-		TotalPages:  utils.CalculateTotalPages(total, limit),
+	if err := s.embedRelations(responses, utils.ParseIncludes(query.Include)); err != nil {
+		return nil, dto.PaginationMeta{}, err
 	}
 
+	meta := utils.NewPaginationMeta(info, opts, page)
+
 	return responses, meta, nil
 }
 
-// Update updates a team
-func (s *teamService) Update(id int, req dto.UpdateTeamRequest) (*dto.TeamResponse, error) {
-	// Get existing team
-	existingTeam, err := s.teamRepo.FindByID(id)
+// Update replaces a team if it still has the version the client read
+func (s *teamService) Update(actor policy.Actor, id, version int, req dto.UpdateTeamRequest) (*dto.TeamResponse, error) {
+	if err := policy.CanManageTeams(actor); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		existingTeam, err := repos.Teams.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(version, existingTeam.Version); err != nil {
+			return err
+		}
+
+		return s.replace(repos, actor, existingTeam, req)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	return s.getUpdated(id)
+}
+
+// Patch applies a JSON merge patch to a team. The patched team is validated like a full update.
+func (s *teamService) Patch(actor policy.Actor, id, version int, patch []byte) (*dto.TeamResponse, error) {
+	if err := policy.CanManageTeams(actor); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		existingTeam, err := repos.Teams.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(version, existingTeam.Version); err != nil {
+			return err
+		}
+
+		var req dto.UpdateTeamRequest
+		if err := utils.ApplyMergePatch(toUpdateTeamRequest(existingTeam), patch, &req); err != nil {
+			return err
+		}
+		if err := utils.ValidationError(utils.ValidateStruct(req), validator.ValidateUpdateTeam(req)); err != nil {
+			return err
+		}
+
+		return s.replace(repos, actor, existingTeam, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getUpdated(id)
+}
+
+// replace overwrites every field of an existing team with the request and records the change
+func (s *teamService) replace(repos *repository.Repositories, actor policy.Actor, existingTeam *models.Team, req dto.UpdateTeamRequest) error {
+	before := toTeamResponse(existingTeam)
+
 	// Check if name is being changed and already exists
-	if req.Name != "" && req.Name != existingTeam.Name {
-		teamWithName, err := s.teamRepo.FindByName(req.Name)
+	if req.Name != existingTeam.Name {
+		teamWithName, err := repos.Teams.FindByName(req.Name)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if teamWithName != nil {
-			return nil, errors.New("nama tim sudah digunakan")
+			return ErrTeamNameTaken
 		}
-		existingTeam.Name = req.Name
 	}
 
-	// Update fields if provided
-	if req.LogoURL != "" {
-		existingTeam.LogoURL = utils.StringToNullString(req.LogoURL)
+	existingTeam.Name = req.Name
+	existingTeam.LogoURL = utils.StringToNullString(req.LogoURL)
+	existingTeam.FoundedYear = req.FoundedYear
+	existingTeam.HomeAddress = req.HomeAddress
+	existingTeam.HomeCity = req.HomeCity
+
+	if err := repos.Teams.Update(existingTeam.ID, existingTeam); err != nil {
+		return err
 	}
-	if req.FoundedYear != 0 {
-		existingTeam.FoundedYear = req.FoundedYear
+
+	updatedTeam, err := repos.Teams.FindByID(existingTeam.ID)
+	if err != nil {
+		return err
 	}
-	if req.HomeAddress != "" {
-		existingTeam.HomeAddress = req.HomeAddress
+
+	return recordAudit(repos, actor, models.AuditEntityTeam, existingTeam.ID, before, toTeamResponse(updatedTeam))
+}
+
+// getUpdated reads a team back after it has been committed
+func (s *teamService) getUpdated(id int) (*dto.TeamResponse, error) {
+	updatedTeam, err := s.teamRepo.FindByID(id)
+	if err != nil {
+		return nil, err
 	}
-	if req.HomeCity != "" {
-		existingTeam.HomeCity = req.HomeCity
+
+	return toTeamResponse(updatedTeam), nil
+}
+
+// PreviewDelete shows what deleting a team with the policy would do, without changing anything
+func (s *teamService) PreviewDelete(actor policy.Actor, id int, deletePolicy string) (*dto.TeamDeletePreview, error) {
+	if err := policy.CanManageTeams(actor); err != nil {
+		return nil, err
 	}
 
-	err = s.teamRepo.Update(id, existingTeam)
+	var preview *dto.TeamDeletePreview
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		team, err := repos.Teams.FindByID(id)
+		if err != nil {
+			return err
+		}
+
+		preview, _, err = planTeamDelete(repos, team, deletePolicy)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Get updated team
-	updatedTeam, err := s.teamRepo.FindByID(id)
+	return preview, nil
+}
+
+// Delete deletes a team with the policy if it still has the version the client read, and
+// returns what was done. A blocked policy returns ErrHasDependents and changes nothing.
+func (s *teamService) Delete(actor policy.Actor, id, version int, deletePolicy string) (*dto.TeamDeletePreview, error) {
+	if err := policy.CanManageTeams(actor); err != nil {
+		return nil, err
+	}
+
+	var preview *dto.TeamDeletePreview
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		team, err := repos.Teams.FindByID(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(version, team.Version); err != nil {
+			return err
+		}
+
+		var players []models.Player
+		preview, players, err = planTeamDelete(repos, team, deletePolicy)
+		if err != nil {
+			return err
+		}
+		if !preview.Allowed {
+			return ErrHasDependents.WithDetail(preview.Reason)
+		}
+
+		if deletePolicy == config.TeamDeleteArchive {
+			if err := repos.Teams.Archive(id, team.Version); err != nil {
+				return err
+			}
+
+			archivedTeam, err := repos.Teams.FindByID(id)
+			if err != nil {
+				return err
+			}
+
+			return recordAudit(repos, actor, models.AuditEntityTeam, id, toTeamResponse(team), toTeamResponse(archivedTeam))
+		}
+
+		// Cascade: the players go to the trash with the team and are restored one by one
+		for _, p := range players {
+			player, err := repos.Players.FindByID(p.ID)
+			if err != nil {
+				return err
+			}
+			if err := repos.Players.Delete(player.ID, player.Version); err != nil {
+				return err
+			}
+			if err := recordAudit(repos, actor, models.AuditEntityPlayer, player.ID, toPlayerResponse(player), nil); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Teams.Delete(id, team.Version); err != nil {
+			return err
+		}
+
+		return recordAudit(repos, actor, models.AuditEntityTeam, id, toTeamResponse(team), nil)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.mapToResponse(updatedTeam), nil
+	return preview, nil
+}
+
+// planTeamDelete works out what deleting the team with the policy does, and returns the
+// active players of the team. Matches are history shared with the opponent, so no policy
+// deletes them: a team that played is archived instead.
+func planTeamDelete(repos *repository.Repositories, team *models.Team, deletePolicy string) (*dto.TeamDeletePreview, []models.Player, error) {
+	if !utils.Contains(config.TeamDeletePolicies(), deletePolicy) {
+		return nil, nil, ErrInvalidDeletePolicy
+	}
+
+	players, err := repos.Players.FindByTeamID(team.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matches, err := repos.Matches.FindByTeamIDs([]int{team.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preview := &dto.TeamDeletePreview{
+		TeamID:  team.ID,
+		Policy:  deletePolicy,
+		Allowed: true,
+		Players: []dto.TeamDependentResponse{},
+		Matches: []dto.TeamDependentResponse{},
+	}
+	for _, player := range players {
+		preview.Players = append(preview.Players, dto.TeamDependentResponse{ID: player.ID, Name: player.Name})
+	}
+	for _, match := range matches {
+		preview.Matches = append(preview.Matches, dto.TeamDependentResponse{ID: match.ID, Name: matchName(&match)})
+	}
+
+	switch deletePolicy {
+	case config.TeamDeleteRestrict:
+		preview.Action = "delete_team"
+		if len(players) > 0 || len(matches) > 0 {
+			preview.Reason = fmt.Sprintf("tim masih memiliki %d pemain dan %d pertandingan aktif; gunakan policy=cascade atau policy=archive", len(players), len(matches))
+		}
+	case config.TeamDeleteCascade:
+		preview.Action = "delete_team_and_players"
+		if len(matches) > 0 {
+			preview.Reason = fmt.Sprintf("tim masih memiliki %d pertandingan aktif; gunakan policy=archive", len(matches))
+		}
+	case config.TeamDeleteArchive:
+		preview.Action = "archive_team"
+	}
+	preview.Allowed = preview.Reason == ""
+
+	return preview, players, nil
 }
 
-// Delete deletes a team
-func (s *teamService) Delete(id int) error {
-	return s.teamRepo.Delete(id)
+// matchName names a match by its teams and date, e.g. "Persija vs Persib (2024-05-01)"
+func matchName(match *models.Match) string {
+	home, away := "", ""
+	if match.HomeTeam != nil {
+		home = match.HomeTeam.Name
+	}
+	if match.AwayTeam != nil {
+		away = match.AwayTeam.Name
+	}
+	return fmt.Sprintf("%s vs %s (%s)", home, away, match.MatchDate)
+}
+
+// toUpdateTeamRequest maps a team to the full update request it would be replaced with
+func toUpdateTeamRequest(team *models.Team) dto.UpdateTeamRequest {
+	return dto.UpdateTeamRequest{
+		Name:        team.Name,
+		LogoURL:     utils.NullStringToString(team.LogoURL),
+		FoundedYear: team.FoundedYear,
+		HomeAddress: team.HomeAddress,
+		HomeCity:    team.HomeCity,
+	}
+}
+
+// embedRelations embeds the requested relations in the teams, loading each relation for all
+// teams with a single query
+func (s *teamService) embedRelations(teams []dto.TeamResponse, includes []string) error {
+	if len(teams) == 0 || len(includes) == 0 {
+		return nil
+	}
+
+	teamIDs := make([]int, len(teams))
+	for i, team := range teams {
+		teamIDs[i] = team.ID
+	}
+
+	if utils.Contains(includes, config.IncludePlayers) {
+		players, err := playersByTeam(s.playerRepo, teamIDs)
+		if err != nil {
+			return err
+		}
+		for i := range teams {
+			teams[i].Players = players[teams[i].ID]
+		}
+	}
+
+	withGoals := utils.Contains(includes, config.IncludeMatchesGoals)
+	if withGoals || utils.Contains(includes, config.IncludeMatches) {
+		matches, err := matchesByTeam(s.matchRepo, s.goalRepo, teamIDs, withGoals)
+		if err != nil {
+			return err
+		}
+		for i := range teams {
+			teams[i].Matches = matches[teams[i].ID]
+		}
+	}
+
+	return nil
 }
 
-// mapToResponse maps team model to response DTO
-func (s *teamService) mapToResponse(team *models.Team) *dto.TeamResponse {
+// toTeamResponse maps team model to response DTO
+func toTeamResponse(team *models.Team) *dto.TeamResponse {
 	return &dto.TeamResponse{
 		ID:          team.ID,
 		Name:        team.Name,
@@ -147,6 +454,8 @@ func (s *teamService) mapToResponse(team *models.Team) *dto.TeamResponse {
 		FoundedYear: team.FoundedYear,
 		HomeAddress: team.HomeAddress,
 		HomeCity:    team.HomeCity,
+		Version:     team.Version,
+		ArchivedAt:  utils.NullTimeToStringPtr(team.ArchivedAt),
 		CreatedAt:   utils.FormatDateTime(team.CreatedAt),
 		UpdatedAt:   utils.FormatDateTime(team.UpdatedAt),
 	}
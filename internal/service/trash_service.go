@@ -0,0 +1,246 @@
+package service
+
+import (
+	"fmt"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
+	"football-management-api/internal/repository"
+	"football-management-api/internal/utils"
+	"football-management-api/pkg/logger"
+	"time"
+)
+
+// ErrInUse is returned when a deleted record is still referenced by active records
+var ErrInUse = repository.ErrInUse
+
+// parentDeleted returns the error for a restore whose parent record is still deleted
+func parentDeleted(message string) error {
+	return apperror.Conflict("PARENT_DELETED", message)
+}
+
+// trashAuditEntities maps trash entities to the entity recorded in the audit log
+var trashAuditEntities = map[string]models.AuditEntity{
+	config.TrashTeams:   models.AuditEntityTeam,
+	config.TrashPlayers: models.AuditEntityPlayer,
+	config.TrashMatches: models.AuditEntityMatch,
+	config.TrashGoals:   models.AuditEntityGoal,
+}
+
+type TrashService interface {
+	GetAll(actor policy.Actor, entity string, page, limit int) ([]dto.TrashItemResponse, dto.PaginationMeta, error)
+	Restore(actor policy.Actor, entity string, id int) error
+	Purge(actor policy.Actor, entity string, id int) error
+	PurgeExpired(actor policy.Actor) (*dto.PurgeTrashResponse, error)
+}
+
+type trashService struct {
+	uow       repository.UnitOfWork
+	trashRepo repository.TrashRepository
+	retention time.Duration
+}
+
+func NewTrashService(uow repository.UnitOfWork, trashRepo repository.TrashRepository, retentionDays int) TrashService {
+	return &trashService{
+		uow:       uow,
+		trashRepo: trashRepo,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// GetAll gets the deleted records of an entity, most recently deleted first
+func (s *trashService) GetAll(actor policy.Actor, entity string, page, limit int) ([]dto.TrashItemResponse, dto.PaginationMeta, error) {
+	if err := policy.CanManageTrash(actor); err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	offset := utils.CalculateOffset(page, limit)
+
+	items, total, err := s.trashRepo.FindAll(entity, limit, offset)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	responses := []dto.TrashItemResponse{}
+	for _, item := range items {
+		responses = append(responses, dto.TrashItemResponse{
+			ID:        item.ID,
+			Name:      item.Name,
+			DeletedAt: utils.FormatDateTime(item.DeletedAt),
+			PurgeAt:   utils.FormatDateTime(item.DeletedAt.Add(s.retention)),
+		})
+	}
+
+	meta := dto.PaginationMeta{
+		CurrentPage: page,
+		PerPage:     limit,
+		Total:       total,
+		TotalPages:  utils.CalculateTotalPages(total, limit),
+	}
+
+	return responses, meta, nil
+}
+
+// Restore undoes the deletion of a record. The record must still fit among the active ones:
+// its name or jersey number must be free and the records it references must be active. A player
+// is not restored into an archived team, and a goal is checked like a new one: its match must
+// have started and the scorer must still play in it.
+func (s *trashService) Restore(actor policy.Actor, entity string, id int) error {
+	if err := policy.CanManageTrash(actor); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repos *repository.Repositories) error {
+		item, err := repos.Trash.FindByID(entity, id)
+		if err != nil {
+			return err
+		}
+
+		var match *models.Match
+		switch entity {
+		case config.TrashTeams:
+			existingTeam, err := repos.Teams.FindByName(item.Name)
+			if err != nil {
+				return err
+			}
+			if existingTeam != nil {
+				return ErrTeamNameTaken
+			}
+
+		case config.TrashPlayers:
+			team, err := repos.Teams.FindByID(item.TeamID)
+			if err != nil {
+				return referenced(err, repository.ErrTeamNotFound, parentDeleted("tim pemain ini sudah dihapus, pulihkan timnya terlebih dahulu"))
+			}
+			if team.IsArchived() {
+				return ErrTeamArchived
+			}
+			exists, err := repos.Players.CheckJerseyNumberExists(item.TeamID, item.JerseyNumber, item.ID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return ErrJerseyNumberTaken
+			}
+
+		case config.TrashMatches:
+			if _, err := repos.Teams.FindByID(item.HomeTeamID); err != nil {
+				return referenced(err, repository.ErrTeamNotFound, parentDeleted("tim home sudah dihapus, pulihkan timnya terlebih dahulu"))
+			}
+			if _, err := repos.Teams.FindByID(item.AwayTeamID); err != nil {
+				return referenced(err, repository.ErrTeamNotFound, parentDeleted("tim away sudah dihapus, pulihkan timnya terlebih dahulu"))
+			}
+
+		case config.TrashGoals:
+			if match, err = repos.Matches.FindByID(item.MatchID); err != nil {
+				return referenced(err, repository.ErrMatchNotFound, parentDeleted("pertandingan gol ini sudah dihapus, pulihkan pertandingannya terlebih dahulu"))
+			}
+			if _, err := repos.Players.FindByID(item.PlayerID); err != nil {
+				return referenced(err, repository.ErrPlayerNotFound, parentDeleted("pencetak gol ini sudah dihapus, pulihkan pemainnya terlebih dahulu"))
+			}
+
+			// The goal must still be one that could be recorded for the match today
+			if !match.HasStarted() {
+				return ErrMatchNotStarted
+			}
+			if _, err := validatePlayerInMatch(repos, item.PlayerID, match); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Trash.Restore(entity, id); err != nil {
+			return err
+		}
+
+		before := map[string]interface{}{"deleted_at": utils.FormatDateTime(item.DeletedAt)}
+		after := map[string]interface{}{"deleted_at": nil}
+		if err := recordAuditAction(repos, actor, models.AuditActionRestore, trashAuditEntities[entity], id, before, after); err != nil {
+			return err
+		}
+
+		// A restored goal counts towards the score again
+		if match != nil {
+			return recalculateScore(repos, actor, match)
+		}
+
+		return nil
+	})
+}
+
+// Purge permanently deletes a deleted record right away, regardless of the retention period
+func (s *trashService) Purge(actor policy.Actor, entity string, id int) error {
+	if err := policy.CanManageTrash(actor); err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(repos *repository.Repositories) error {
+		item, err := repos.Trash.FindByID(entity, id)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.Trash.Purge(entity, id); err != nil {
+			return err
+		}
+
+		before := map[string]interface{}{"name": item.Name, "deleted_at": utils.FormatDateTime(item.DeletedAt)}
+		return recordAuditAction(repos, actor, models.AuditActionPurge, trashAuditEntities[entity], id, before, nil)
+	})
+}
+
+// PurgeExpired permanently deletes every record deleted longer ago than the retention period.
+// Children are purged before their parents, so a parent goes in the same run as its expired
+// children; records still referenced by other records are kept.
+func (s *trashService) PurgeExpired(actor policy.Actor) (*dto.PurgeTrashResponse, error) {
+	if err := policy.CanManageTrash(actor); err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-s.retention)
+	response := &dto.PurgeTrashResponse{Purged: map[string]int{}}
+
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		for _, entity := range config.TrashEntities() {
+			ids, err := repos.Trash.PurgeDeletedBefore(entity, cutoff)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range ids {
+				if err := recordAuditAction(repos, actor, models.AuditActionPurge, trashAuditEntities[entity], id, nil, nil); err != nil {
+					return err
+				}
+			}
+			response.Purged[entity] = len(ids)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// StartTrashPurge purges expired records every interval in the background
+func StartTrashPurge(trashService TrashService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			result, err := trashService.PurgeExpired(policy.System)
+			if err != nil {
+				logger.Error("Failed to purge trash: " + err.Error())
+				continue
+			}
+			logger.Info(fmt.Sprintf("Purged trash: %v", result.Purged))
+		}
+	}()
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"football-management-api/internal/config"
+	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
+	"football-management-api/internal/repository"
+)
+
+// fakeUnitOfWork runs the work against fixed repositories without a transaction
+type fakeUnitOfWork struct {
+	repos *repository.Repositories
+}
+
+func (u *fakeUnitOfWork) Do(fn func(repos *repository.Repositories) error) error {
+	return fn(u.repos)
+}
+
+type fakeTrashRepository struct {
+	repository.TrashRepository
+	item     *models.TrashItem
+	restored bool
+}
+
+func (r *fakeTrashRepository) FindByID(entity string, id int) (*models.TrashItem, error) {
+	return r.item, nil
+}
+
+func (r *fakeTrashRepository) Restore(entity string, id int) error {
+	r.restored = true
+	return nil
+}
+
+type fakeTeamRepository struct {
+	repository.TeamRepository
+	team *models.Team
+}
+
+func (r *fakeTeamRepository) FindByID(id int) (*models.Team, error) {
+	return r.team, nil
+}
+
+func TestRestorePlayerIntoArchivedTeam(t *testing.T) {
+	archived := &models.Team{ID: 1, Name: "Persija", ArchivedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+	trash := &fakeTrashRepository{item: &models.TrashItem{ID: 7, Name: "Bambang", TeamID: 1, JerseyNumber: 20}}
+	repos := &repository.Repositories{
+		Trash: trash,
+		Teams: &fakeTeamRepository{team: archived},
+	}
+	service := &trashService{uow: &fakeUnitOfWork{repos: repos}}
+
+	if err := service.Restore(policy.System, config.TrashPlayers, 7); err != ErrTeamArchived {
+		t.Errorf("Restore() error = %v, want %v", err, ErrTeamArchived)
+	}
+	if trash.restored {
+		t.Error("player was restored into an archived team")
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"database/sql"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/models"
+	"football-management-api/internal/policy"
+	"football-management-api/internal/repository"
+	"football-management-api/internal/utils"
+)
+
+// ErrOwnRoleChange is returned when admins change their own role, which could leave the league
+// without an admin
+var ErrOwnRoleChange = apperror.Forbidden("OWN_ROLE_CHANGE", "tidak dapat mengubah role akun sendiri")
+
+type UserService interface {
+	GetAll(actor policy.Actor, page, limit int) ([]dto.UserResponse, dto.PaginationMeta, error)
+	UpdateRole(actor policy.Actor, id int, req dto.UpdateUserRoleRequest) (*dto.UserResponse, error)
+}
+
+type userService struct {
+	uow      repository.UnitOfWork
+	userRepo repository.UserRepository
+}
+
+func NewUserService(uow repository.UnitOfWork, userRepo repository.UserRepository) UserService {
+	return &userService{
+		uow:      uow,
+		userRepo: userRepo,
+	}
+}
+
+// GetAll gets all users with pagination
+func (s *userService) GetAll(actor policy.Actor, page, limit int) ([]dto.UserResponse, dto.PaginationMeta, error) {
+	if err := policy.CanManageUsers(actor); err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	offset := utils.CalculateOffset(page, limit)
+
+	users, total, err := s.userRepo.FindAll(limit, offset)
+	if err != nil {
+		return nil, dto.PaginationMeta{}, err
+	}
+
+	responses := []dto.UserResponse{}
+	for _, user := range users {
+		responses = append(responses, *toUserResponse(&user))
+	}
+
+	meta := dto.PaginationMeta{
+		CurrentPage: page,
+		PerPage:     limit,
+		Total:       total,
+		TotalPages:  utils.CalculateTotalPages(total, limit),
+	}
+
+	return responses, meta, nil
+}
+
+// UpdateRole assigns a role to a user. The new role applies to tokens issued after the change.
+func (s *userService) UpdateRole(actor policy.Actor, id int, req dto.UpdateUserRoleRequest) (*dto.UserResponse, error) {
+	if err := policy.CanManageUsers(actor); err != nil {
+		return nil, err
+	}
+
+	// Keep at least the acting admin, so the league can never lock itself out
+	if id == actor.UserID {
+		return nil, ErrOwnRoleChange
+	}
+
+	err := s.uow.Do(func(repos *repository.Repositories) error {
+		teamID := sql.NullInt64{}
+		if req.Role == config.RoleTeamManager {
+			if _, err := repos.Teams.FindByID(req.TeamID); err != nil {
+				return referenced(err, repository.ErrTeamNotFound, ErrUnknownTeam)
+			}
+			teamID = sql.NullInt64{Int64: int64(req.TeamID), Valid: true}
+		}
+
+		return repos.Users.UpdateRole(id, models.UserRole(req.Role), teamID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserResponse(user), nil
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/models"
+	"strings"
+)
+
+// Errors returned for a pagination cursor that cannot be used
+var (
+	ErrInvalidCursor      = apperror.Validation("INVALID_CURSOR", "cursor tidak valid")
+	ErrCursorSortMismatch = apperror.Validation("CURSOR_SORT_MISMATCH", "cursor tidak sesuai dengan parameter sort")
+)
+
+// EncodeCursor encodes a cursor into an opaque URL-safe string
+func EncodeCursor(cursor *models.Cursor) string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a cursor string. An empty string is the cursor of the first page.
+func DecodeCursor(encoded string) (*models.Cursor, error) {
+	cursor := &models.Cursor{}
+	if encoded == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	// Keep numbers as json.Number so IDs are passed back to the database unchanged
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// FormatSort formats sort fields back into the sort parameter they were parsed from
+func FormatSort(fields []models.SortField) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field.Desc {
+			parts = append(parts, "-"+field.Field)
+		} else {
+			parts = append(parts, field.Field)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// NewListOptions builds list options from the search, sort and pagination parameters.
+// A non-nil cursor selects keyset pagination, in which case page is ignored.
+func NewListOptions(search, sort string, cursor *string, page, limit int) (models.ListOptions, error) {
+	opts := models.ListOptions{
+		Search: strings.TrimSpace(search),
+		Sort:   ParseSort(sort),
+		Limit:  limit,
+		Offset: CalculateOffset(page, limit),
+	}
+
+	if cursor == nil {
+		return opts, nil
+	}
+
+	decoded, err := DecodeCursor(*cursor)
+	if err != nil {
+		return opts, err
+	}
+
+	// A cursor only points into the list it was created for
+	if len(decoded.Values) > 0 && decoded.Sort != FormatSort(opts.Sort) {
+		return opts, ErrCursorSortMismatch
+	}
+
+	opts.Cursor = decoded
+	opts.Offset = 0
+	return opts, nil
+}
+
+// NewPaginationMeta builds pagination metadata for an offset or keyset page
+func NewPaginationMeta(info models.PageInfo, opts models.ListOptions, page int) dto.PaginationMeta {
+	if opts.Cursor == nil {
+		return dto.PaginationMeta{
+			CurrentPage: page,
+			PerPage:     opts.Limit,
+			Total:       info.Total,
+			TotalPages:  CalculateTotalPages(info.Total, opts.Limit),
+		}
+	}
+
+	meta := dto.PaginationMeta{PerPage: opts.Limit}
+	sort := FormatSort(opts.Sort)
+	if info.NextCursor != nil {
+		info.NextCursor.Sort = sort
+		meta.NextCursor = EncodeCursor(info.NextCursor)
+	}
+	if info.PrevCursor != nil {
+		info.PrevCursor.Sort = sort
+		meta.PrevCursor = EncodeCursor(info.PrevCursor)
+	}
+
+	return meta
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"football-management-api/internal/models"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor *models.Cursor
+		want   *models.Cursor
+	}{
+		{
+			name:   "next cursor",
+			cursor: &models.Cursor{Sort: "-founded_year,name", Values: []interface{}{1928, "Persija", 1}},
+			want:   &models.Cursor{Sort: "-founded_year,name", Values: []interface{}{json.Number("1928"), "Persija", json.Number("1")}},
+		},
+		{
+			name:   "prev cursor",
+			cursor: &models.Cursor{Values: []interface{}{"2024-10-01", 12}, Prev: true},
+			want:   &models.Cursor{Values: []interface{}{"2024-10-01", json.Number("12")}, Prev: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(EncodeCursor(tt.cursor))
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeCursor() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr error
+	}{
+		{"empty cursor starts at the first page", "", nil},
+		{"not base64", "!!!", ErrInvalidCursor},
+		{"not JSON", "bm90IGpzb24", ErrInvalidCursor},
+		{"wrong shape", "eyJ2IjoxfQ", ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := DecodeCursor(tt.encoded)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeCursor(%q) error = %v, want %v", tt.encoded, err, tt.wantErr)
+			}
+			if tt.wantErr == nil && len(cursor.Values) != 0 {
+				t.Errorf("DecodeCursor(%q) = %+v, want an empty cursor", tt.encoded, cursor)
+			}
+		})
+	}
+}
+
+func TestFormatSort(t *testing.T) {
+	for _, raw := range []string{"", "name", "-founded_year,name", "-height,-weight"} {
+		if got := FormatSort(ParseSort(raw)); got != raw {
+			t.Errorf("FormatSort(ParseSort(%q)) = %q", raw, got)
+		}
+	}
+}
+
+func TestNewListOptions(t *testing.T) {
+	cursor := func(c *models.Cursor) *string {
+		encoded := EncodeCursor(c)
+		return &encoded
+	}
+	empty := ""
+
+	tests := []struct {
+		name       string
+		sort       string
+		cursor     *string
+		page       int
+		wantOffset int
+		wantKeyset bool
+		wantErr    error
+	}{
+		{"offset pagination", "name", nil, 3, 20, false, nil},
+		{"first keyset page", "name", &empty, 3, 0, true, nil},
+		{"cursor of the same sort", "-name", cursor(&models.Cursor{Sort: "-name", Values: []interface{}{"A", 1}}), 1, 0, true, nil},
+		{"cursor of another sort", "name", cursor(&models.Cursor{Sort: "-name", Values: []interface{}{"A", 1}}), 1, 0, false, ErrCursorSortMismatch},
+		{"malformed cursor", "name", func() *string { s := "%%%"; return &s }(), 1, 0, false, ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := NewListOptions("", tt.sort, tt.cursor, tt.page, 10)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewListOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if opts.Offset != tt.wantOffset {
+				t.Errorf("Offset = %d, want %d", opts.Offset, tt.wantOffset)
+			}
+			if (opts.Cursor != nil) != tt.wantKeyset {
+				t.Errorf("Cursor = %+v, want keyset pagination %v", opts.Cursor, tt.wantKeyset)
+			}
+		})
+	}
+}
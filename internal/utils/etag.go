@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag returns the strong entity tag of a resource version
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// SetETag sets the ETag header of a single resource response
+func SetETag(c *gin.Context, version int) {
+	c.Header("ETag", ETag(version))
+}
+
+// GetIfMatchVersion reads the resource version from the If-Match header. "*" matches any
+// version and is returned as 0. When the header is missing or invalid an error response is
+// sent and ok is false.
+func GetIfMatchVersion(c *gin.Context) (version int, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		SendPreconditionRequired(c, "Header If-Match wajib diisi", "sertakan ETag dari respons GET terakhir pada header If-Match")
+		return 0, false
+	}
+
+	if header == "*" {
+		return 0, true
+	}
+
+	// Weak tags never match for If-Match (RFC 9110 section 13.1.1)
+	unquoted, err := strconv.Unquote(header)
+	if err == nil {
+		version, err = strconv.Atoi(unquoted)
+	}
+	if err != nil || version < 1 {
+		SendPreconditionFailed(c, "Header If-Match tidak valid", "If-Match harus berisi ETag dari respons GET terakhir")
+		return 0, false
+	}
+
+	return version, true
+}
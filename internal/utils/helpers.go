@@ -2,8 +2,10 @@ package utils
 
 import (
 	"database/sql"
+	"football-management-api/internal/models"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +36,24 @@ func NullInt32ToIntPtr(ni sql.NullInt32) *int {
 	return nil
 }
 
+// NullInt64ToIntPtr converts sql.NullInt64 to *int
+func NullInt64ToIntPtr(ni sql.NullInt64) *int {
+	if ni.Valid {
+		val := int(ni.Int64)
+		return &val
+	}
+	return nil
+}
+
+// NullTimeToStringPtr converts sql.NullTime to a *string formatted with FormatDateTime
+func NullTimeToStringPtr(nt sql.NullTime) *string {
+	if nt.Valid {
+		val := FormatDateTime(nt.Time)
+		return &val
+	}
+	return nil
+}
+
 // IntToNullInt32 converts int to sql.NullInt32
 func IntToNullInt32(i int) sql.NullInt32 {
 	return sql.NullInt32{Int32: int32(i), Valid: true}
@@ -106,6 +126,28 @@ func CalculateTotalPages(total int64, limit int) int {
 	return int(math.Ceil(float64(total) / float64(limit)))
 }
 
+// Percentage calculates part as a percentage of total rounded to two decimals
+func Percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return Round(float64(part)*100/float64(total), 2)
+}
+
+// Average calculates sum divided by count rounded to two decimals
+func Average(sum, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return Round(float64(sum)/float64(count), 2)
+}
+
+// Round rounds a number to the given decimal places
+func Round(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
+
 // Contains checks if a string is in a slice
 func Contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -116,6 +158,37 @@ func Contains(slice []string, item string) bool {
 	return false
 }
 
+// ParseSort parses a comma separated sort parameter such as "-founded_year,name".
+// A leading "-" sorts the field in descending order.
+func ParseSort(raw string) []models.SortField {
+	var fields []models.SortField
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		field := models.SortField{Field: part}
+		if strings.HasPrefix(part, "-") {
+			field.Field = strings.TrimPrefix(part, "-")
+			field.Desc = true
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// ParseIncludes parses a comma separated include parameter such as "players,matches.goals"
+func ParseIncludes(raw string) []string {
+	var includes []string
+	for _, part := range strings.Split(raw, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			includes = append(includes, part)
+		}
+	}
+	return includes
+}
+
 // GetCurrentYear returns current year
 func GetCurrentYear() int {
 	return time.Now().Year()
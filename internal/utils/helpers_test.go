@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"football-management-api/internal/models"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []models.SortField
+	}{
+		{"", nil},
+		{"name", []models.SortField{{Field: "name"}}},
+		{"-founded_year,name", []models.SortField{{Field: "founded_year", Desc: true}, {Field: "name"}}},
+		{" -height , , weight ", []models.SortField{{Field: "height", Desc: true}, {Field: "weight"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := ParseSort(tt.raw); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSort(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"football-management-api/internal/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MergePatchContentType is the media type of RFC 7396 JSON merge patch documents
+const MergePatchContentType = "application/merge-patch+json"
+
+// ErrInvalidPatch is returned for a merge patch that is malformed or does not fit the resource
+var ErrInvalidPatch = apperror.Validation("INVALID_PATCH", "patch tidak valid")
+
+// IsMergePatchRequest reports whether the request body is a merge patch. Plain JSON is accepted
+// as well since a merge patch is a JSON object.
+func IsMergePatchRequest(c *gin.Context) bool {
+	contentType := c.ContentType()
+	return contentType == MergePatchContentType || contentType == gin.MIMEJSON
+}
+
+// ApplyMergePatch applies an RFC 7396 JSON merge patch to the JSON encoding of current and
+// decodes the result into target. Members set to null in the patch are removed, so the
+// matching fields of target keep their zero value. Unknown members are rejected.
+func ApplyMergePatch(current interface{}, patch []byte, target interface{}) error {
+	var patchDoc interface{}
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return ErrInvalidPatch.WithDetail("bukan JSON yang valid")
+	}
+	if _, ok := patchDoc.(map[string]interface{}); !ok {
+		return ErrInvalidPatch.WithDetail("harus berupa objek JSON")
+	}
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(currentJSON, &doc); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(doc, patchDoc))
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return ErrInvalidPatch.WithDetail(err.Error())
+	}
+
+	return nil
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396 section 2
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+		} else {
+			targetObject[name] = mergePatch(targetObject[name], value)
+		}
+	}
+
+	return targetObject
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7396 appendix A
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	decode := func(t *testing.T, s string) interface{} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatalf("invalid test JSON %s: %v", s, err)
+		}
+		return v
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target+" "+tt.patch, func(t *testing.T) {
+			got := mergePatch(decode(t, tt.target), decode(t, tt.patch))
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("mergePatch() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	type team struct {
+		Name     string  `json:"name"`
+		City     string  `json:"city"`
+		LogoURL  *string `json:"logo_url"`
+		Founded  int     `json:"founded"`
+		Nickname string  `json:"nickname,omitempty"`
+	}
+
+	logo := "https://example.com/logo.png"
+	current := team{Name: "Persija", City: "Jakarta", LogoURL: &logo, Founded: 1928}
+
+	tests := []struct {
+		name    string
+		patch   string
+		want    team
+		wantErr bool
+	}{
+		{
+			name:  "changes only the patched members",
+			patch: `{"city":"Bekasi"}`,
+			want:  team{Name: "Persija", City: "Bekasi", LogoURL: &logo, Founded: 1928},
+		},
+		{
+			name:  "null removes a member",
+			patch: `{"logo_url":null}`,
+			want:  team{Name: "Persija", City: "Jakarta", Founded: 1928},
+		},
+		{
+			name:  "empty patch keeps the resource",
+			patch: `{}`,
+			want:  current,
+		},
+		{name: "unknown member", patch: `{"stadium":"GBK"}`, wantErr: true},
+		{name: "wrong type", patch: `{"founded":"1928"}`, wantErr: true},
+		{name: "not an object", patch: `["name"]`, wantErr: true},
+		{name: "malformed JSON", patch: `{"name":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got team
+			err := ApplyMergePatch(current, []byte(tt.patch), &got)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPatch) {
+					t.Fatalf("ApplyMergePatch() error = %v, want %v", err, ErrInvalidPatch)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyMergePatch() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyMergePatch() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,11 +3,16 @@ package utils
 import (
 	"net/http"
 
+	"football-management-api/internal/apperror"
 	"football-management-api/internal/dto"
+	"football-management-api/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
+// internalErrorDetail is sent in place of the text of an unexpected error
+const internalErrorDetail = "terjadi kesalahan pada server"
+
 // SendSuccess sends a successful JSON response
 func SendSuccess(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusOK, dto.SuccessResponse(message, data))
@@ -18,29 +23,72 @@ func SendCreated(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusCreated, dto.SuccessResponse(message, data))
 }
 
+// SendError sends an error returned by a service. Typed errors are sent with the status of
+// their kind and their code, validation errors also with every failed field; any other error
+// is unexpected and sent as an internal error. Its text is only logged, since it may contain
+// driver or query details.
+func SendError(c *gin.Context, message string, err error) {
+	if appErr, ok := apperror.As(err); ok {
+		response := dto.ErrorResponse(appErr.Code, message, err.Error())
+		for _, field := range appErr.Fields {
+			response.Errors = append(response.Errors, dto.FieldError{
+				Field:   field.Field,
+				Code:    field.Code,
+				Message: field.Message,
+				Params:  field.Params,
+			})
+		}
+		c.JSON(appErr.Status(), response)
+		return
+	}
+
+	logger.Error(message + ": " + err.Error())
+	SendInternalError(c, message, internalErrorDetail)
+}
+
 // SendBadRequest sends a bad request error response
 func SendBadRequest(c *gin.Context, message string, err string) {
-	c.JSON(http.StatusBadRequest, dto.ErrorResponse(message, err))
+	c.JSON(http.StatusBadRequest, dto.ErrorResponse(apperror.CodeBadRequest, message, err))
 }
 
 // SendNotFound sends a not found error response
 func SendNotFound(c *gin.Context, message string, err string) {
-	c.JSON(http.StatusNotFound, dto.ErrorResponse(message, err))
+	c.JSON(http.StatusNotFound, dto.ErrorResponse(apperror.CodeNotFound, message, err))
 }
 
 // SendInternalError sends an internal server error response
 func SendInternalError(c *gin.Context, message string, err string) {
-	c.JSON(http.StatusInternalServerError, dto.ErrorResponse(message, err))
+	c.JSON(http.StatusInternalServerError, dto.ErrorResponse(apperror.CodeInternal, message, err))
 }
 
 // SendUnauthorized sends an unauthorized error response
 func SendUnauthorized(c *gin.Context, message string, err string) {
-	c.JSON(http.StatusUnauthorized, dto.ErrorResponse(message, err))
+	c.JSON(http.StatusUnauthorized, dto.ErrorResponse(apperror.CodeUnauthorized, message, err))
+}
+
+// SendForbidden sends a forbidden error response
+func SendForbidden(c *gin.Context, message string, err string) {
+	c.JSON(http.StatusForbidden, dto.ErrorResponse(apperror.CodeForbidden, message, err))
 }
 
 // SendConflict sends a conflict error response
 func SendConflict(c *gin.Context, message string, err string) {
-	c.JSON(http.StatusConflict, dto.ErrorResponse(message, err))
+	c.JSON(http.StatusConflict, dto.ErrorResponse(apperror.CodeConflict, message, err))
+}
+
+// SendUnsupportedMediaType sends an unsupported media type error response
+func SendUnsupportedMediaType(c *gin.Context, message string, err string) {
+	c.JSON(http.StatusUnsupportedMediaType, dto.ErrorResponse(apperror.CodeUnsupportedMediaType, message, err))
+}
+
+// SendPreconditionFailed sends a precondition failed error response
+func SendPreconditionFailed(c *gin.Context, message string, err string) {
+	c.JSON(http.StatusPreconditionFailed, dto.ErrorResponse(apperror.CodePreconditionFailed, message, err))
+}
+
+// SendPreconditionRequired sends a precondition required error response
+func SendPreconditionRequired(c *gin.Context, message string, err string) {
+	c.JSON(http.StatusPreconditionRequired, dto.ErrorResponse(apperror.CodePreconditionRequired, message, err))
 }
 
 // SendPaginated sends a paginated response
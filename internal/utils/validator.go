@@ -1,51 +1,168 @@
 package utils
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 
+	"football-management-api/internal/apperror"
+
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
-// FormatValidationError formats validation errors to a readable string
-func FormatValidationError(err error) string {
-	var errors []string
+// RegisterBindingFieldNames makes gin report binding errors with the name a field has in the
+// request instead of its Go name
+func RegisterBindingFieldNames() {
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// requestFieldName returns the JSON name of a struct field, or its query parameter name
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// ValidationError merges the error of binding a request with the error of its validator rules
+// into one validation error that lists every invalid field. Rules of a field that binding
+// already rejected are left out. A body that could not be decoded at all is reported on its
+// own. It returns nil when the request is valid.
+func ValidationError(bindErr, ruleErr error) error {
+	var fields []apperror.FieldError
+
+	if bindErr != nil {
+		bindFields, ok := bindingFieldErrors(bindErr)
+		if !ok {
+			return apperror.Validation(apperror.CodeMalformedRequest, bindErr.Error())
+		}
+		fields = bindFields
+	}
+
+	if ruleErr != nil {
+		appErr, ok := apperror.As(ruleErr)
+		if !ok || len(appErr.Fields) == 0 {
+			if len(fields) == 0 {
+				return ruleErr
+			}
+			return apperror.InvalidFields(fields)
+		}
+
+		rejected := map[string]bool{}
+		for _, field := range fields {
+			rejected[field.Field] = true
+		}
+		for _, field := range appErr.Fields {
+			if !rejected[field.Field] {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	return apperror.InvalidFields(fields)
+}
 
-	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+// bindingFieldErrors converts the field errors of binding a request. ok is false for errors
+// that are not about a single field, such as malformed JSON.
+func bindingFieldErrors(err error) (fields []apperror.FieldError, ok bool) {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
 		for _, e := range validationErrors {
-			errors = append(errors, formatFieldError(e))
+			fields = append(fields, toFieldError(e))
 		}
+		return fields, true
 	}
 
-	if len(errors) > 0 {
-		return strings.Join(errors, "; ")
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []apperror.FieldError{{
+			Field:   typeErr.Field,
+			Code:    apperror.FieldInvalidType,
+			Message: fmt.Sprintf("%s harus bertipe %s", typeErr.Field, typeErr.Type),
+			Params:  map[string]interface{}{"type": typeErr.Type.String()},
+		}}, true
 	}
 
-	return err.Error()
+	return nil, false
 }
 
-// formatFieldError formats a single field error
-func formatFieldError(err validator.FieldError) string {
-	field := strings.ToLower(err.Field())
+// toFieldError converts a failed binding tag. The field is named by its path below the request,
+// e.g. goals[0].player_id.
+func toFieldError(err validator.FieldError) apperror.FieldError {
+	field := err.Namespace()
+	if i := strings.Index(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
 
 	switch err.Tag() {
 	case "required":
-		return fmt.Sprintf("%s wajib diisi", field)
+		return apperror.FieldError{Field: field, Code: apperror.FieldRequired, Message: fmt.Sprintf("%s wajib diisi", field)}
 	case "min":
-		return fmt.Sprintf("%s minimal %s", field, err.Param())
+		return apperror.FieldError{
+			Field:   field,
+			Code:    apperror.FieldMin,
+			Message: fmt.Sprintf("%s minimal %s", field, err.Param()),
+			Params:  map[string]interface{}{"min": paramValue(err.Param())},
+		}
 	case "max":
-		return fmt.Sprintf("%s maksimal %s", field, err.Param())
-	case "email":
-		return fmt.Sprintf("%s harus berformat email yang valid", field)
-	case "url":
-		return fmt.Sprintf("%s harus berformat URL yang valid", field)
+		return apperror.FieldError{
+			Field:   field,
+			Code:    apperror.FieldMax,
+			Message: fmt.Sprintf("%s maksimal %s", field, err.Param()),
+			Params:  map[string]interface{}{"max": paramValue(err.Param())},
+		}
+	case "email", "url":
+		return apperror.FieldError{
+			Field:   field,
+			Code:    apperror.FieldInvalidFormat,
+			Message: fmt.Sprintf("%s harus berformat %s yang valid", field, strings.ToUpper(err.Tag()[:1])+err.Tag()[1:]),
+			Params:  map[string]interface{}{"format": err.Tag()},
+		}
+	case "oneof":
+		return apperror.FieldError{
+			Field:   field,
+			Code:    apperror.FieldInvalidChoice,
+			Message: fmt.Sprintf("%s tidak valid. Pilihan: %s", field, strings.Join(strings.Fields(err.Param()), ", ")),
+			Params:  map[string]interface{}{"choices": strings.Fields(err.Param())},
+		}
 	default:
-		return fmt.Sprintf("%s tidak valid", field)
+		return apperror.FieldError{
+			Field:   field,
+			Code:    apperror.FieldInvalid,
+			Message: fmt.Sprintf("%s tidak valid", field),
+			Params:  map[string]interface{}{"rule": err.Tag()},
+		}
+	}
+}
+
+// paramValue returns a numeric tag parameter as a number
+func paramValue(param string) interface{} {
+	if n, err := strconv.Atoi(param); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(param, 64); err == nil {
+		return f
 	}
+	return param
 }
 
-// ValidateStruct validates a struct using validator
+// ValidateStruct validates a struct against its binding tags, the same rules gin applies when
+// binding a request. Errors are reported with the JSON field names.
 func ValidateStruct(s interface{}) error {
 	validate := validator.New()
+	validate.SetTagName("binding")
+	validate.RegisterTagNameFunc(requestFieldName)
 	return validate.Struct(s)
 }
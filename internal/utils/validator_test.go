@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"football-management-api/internal/apperror"
+)
+
+type testPlayerRequest struct {
+	Name         string  `json:"name" binding:"required"`
+	Height       float64 `json:"height" binding:"required,min=100,max=250"`
+	Position     string  `json:"position" binding:"oneof=Penyerang Bertahan"`
+	Email        string  `json:"email" binding:"omitempty,email"`
+	JerseyNumber int     `json:"jersey_number" binding:"max=99"`
+}
+
+// bindErr returns the error of binding body the way gin does for a JSON request
+func bindErr(t *testing.T, body string) error {
+	t.Helper()
+	var req testPlayerRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return err
+	}
+	return ValidateStruct(req)
+}
+
+func TestValidationError(t *testing.T) {
+	ruleErr := apperror.InvalidFields([]apperror.FieldError{
+		{Field: "height", Code: apperror.FieldOutOfRange, Message: "tinggi badan harus antara 100-250 cm"},
+		{Field: "position", Code: apperror.FieldInvalidChoice, Message: "posisi pemain tidak valid"},
+	})
+	valid := `{"name":"Bambang","height":172,"position":"Penyerang","jersey_number":20}`
+
+	tests := []struct {
+		name      string
+		bindErr   error
+		ruleErr   error
+		want      []string // field:code
+		wantCode  string
+		wantNoErr bool
+	}{
+		{
+			name:      "valid request",
+			bindErr:   bindErr(t, valid),
+			wantNoErr: true,
+		},
+		{
+			name:     "binding errors use JSON names",
+			bindErr:  bindErr(t, `{"height":300,"position":"Libero","email":"bukan-email","jersey_number":100}`),
+			want:     []string{"name:REQUIRED", "height:MAX", "position:INVALID_CHOICE", "email:INVALID_FORMAT", "jersey_number:MAX"},
+			wantCode: apperror.CodeValidationFailed,
+		},
+		{
+			name:     "rules of fields binding rejected are left out",
+			bindErr:  bindErr(t, `{"name":"Bambang","height":50,"position":"Penyerang"}`),
+			ruleErr:  ruleErr,
+			want:     []string{"height:MIN", "position:INVALID_CHOICE"},
+			wantCode: apperror.CodeValidationFailed,
+		},
+		{
+			name:     "rule errors without binding errors",
+			bindErr:  bindErr(t, valid),
+			ruleErr:  ruleErr,
+			want:     []string{"height:OUT_OF_RANGE", "position:INVALID_CHOICE"},
+			wantCode: apperror.CodeValidationFailed,
+		},
+		{
+			name:     "wrong JSON type",
+			bindErr:  bindErr(t, `{"name":"Bambang","height":"tinggi"}`),
+			ruleErr:  ruleErr,
+			want:     []string{"height:INVALID_TYPE", "position:INVALID_CHOICE"},
+			wantCode: apperror.CodeValidationFailed,
+		},
+		{
+			name:     "malformed body is reported on its own",
+			bindErr:  bindErr(t, `{"name":`),
+			ruleErr:  ruleErr,
+			wantCode: apperror.CodeMalformedRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidationError(tt.bindErr, tt.ruleErr)
+			if tt.wantNoErr {
+				if err != nil {
+					t.Fatalf("ValidationError() = %v, want nil", err)
+				}
+				return
+			}
+
+			appErr, ok := apperror.As(err)
+			if !ok || appErr.Code != tt.wantCode {
+				t.Fatalf("ValidationError() = %v, want code %s", err, tt.wantCode)
+			}
+
+			var got []string
+			for _, field := range appErr.Fields {
+				got = append(got, field.Field+":"+field.Code)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorWithoutFields(t *testing.T) {
+	notFound := apperror.NotFound("TEAM_NOT_FOUND", "tim tidak ditemukan")
+
+	if err := ValidationError(nil, notFound); err != notFound {
+		t.Errorf("ValidationError(nil, %v) = %v, want the rule error unchanged", notFound, err)
+	}
+
+	plain := errors.New("gagal")
+	if err := ValidationError(nil, plain); err != plain {
+		t.Errorf("ValidationError(nil, %v) = %v, want the rule error unchanged", plain, err)
+	}
+
+	err := ValidationError(bindErr(t, `{"height":172,"position":"Penyerang"}`), plain)
+	appErr, ok := apperror.As(err)
+	if !ok || len(appErr.Fields) != 1 || appErr.Fields[0].Field != "name" {
+		t.Errorf("ValidationError() = %v, want the binding errors only", err)
+	}
+}
+
+func TestBindingFieldParams(t *testing.T) {
+	appErr, _ := apperror.As(ValidationError(bindErr(t, `{"name":"Bambang","height":300,"position":"Libero"}`), nil))
+
+	want := map[string]map[string]interface{}{
+		"height":   {"max": 250},
+		"position": {"choices": []string{"Penyerang", "Bertahan"}},
+	}
+	for _, field := range appErr.Fields {
+		if !reflect.DeepEqual(field.Params, want[field.Field]) {
+			t.Errorf("params of %s = %v, want %v", field.Field, field.Params, want[field.Field])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"fmt"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/utils"
+	"strings"
+)
+
+// ValidateAuditListQuery validates audit log filters
+func ValidateAuditListQuery(query dto.AuditListQuery) error {
+	var errs fieldErrors
+
+	if query.Entity != "" && !utils.Contains(config.AuditEntities(), query.Entity) {
+		errs.add("entity", apperror.FieldInvalidChoice,
+			fmt.Sprintf("entity tidak valid. Pilihan: %s", strings.Join(config.AuditEntities(), ", ")),
+			map[string]interface{}{"choices": config.AuditEntities()})
+	}
+
+	if query.EntityID < 0 {
+		errs.add("entity_id", apperror.FieldInvalid, "filter ID tidak valid", nil)
+	}
+
+	if query.EntityID > 0 && query.Entity == "" {
+		errs.add("entity", apperror.FieldRequired, "entity wajib diisi jika entity_id digunakan",
+			map[string]interface{}{"field": "entity_id"})
+	}
+
+	checkDateRange(&errs, query.DateFrom, query.DateTo)
+
+	return errs.err()
+}
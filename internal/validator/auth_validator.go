@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"fmt"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/utils"
+	"regexp"
+	"strings"
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// ValidateRegister validates register request
+func ValidateRegister(req dto.RegisterRequest) error {
+	var errs fieldErrors
+
+	if len(req.Username) < 3 || len(req.Username) > 50 {
+		errs.add("username", apperror.FieldLength, "username harus 3-50 karakter",
+			map[string]interface{}{"min": 3, "max": 50})
+	} else if !usernamePattern.MatchString(req.Username) {
+		errs.add("username", apperror.FieldInvalidFormat,
+			"username hanya boleh berisi huruf, angka, titik, dan garis bawah",
+			map[string]interface{}{"pattern": usernamePattern.String()})
+	}
+
+	// bcrypt only uses the first 72 bytes of a password
+	if len(req.Password) < 8 || len(req.Password) > 72 {
+		errs.add("password", apperror.FieldLength, "password harus 8-72 karakter",
+			map[string]interface{}{"min": 8, "max": 72})
+	}
+
+	return errs.err()
+}
+
+// ValidateUpdateUserRole validates update user role request
+func ValidateUpdateUserRole(req dto.UpdateUserRoleRequest) error {
+	var errs fieldErrors
+
+	if !utils.Contains(config.ValidRoles(), req.Role) {
+		errs.add("role", apperror.FieldInvalidChoice,
+			"role tidak valid. Pilihan: admin, team_manager, match_official, viewer",
+			map[string]interface{}{"choices": config.ValidRoles()})
+	}
+
+	if req.Role == config.RoleTeamManager && req.TeamID <= 0 {
+		errs.add("team_id", apperror.FieldRequired, "team_id wajib diisi untuk team_manager",
+			map[string]interface{}{"role": config.RoleTeamManager})
+	}
+
+	if req.Role != config.RoleTeamManager && req.TeamID != 0 {
+		errs.add("team_id", apperror.FieldNotAllowed, "team_id hanya berlaku untuk team_manager",
+			map[string]interface{}{"role": config.RoleTeamManager})
+	}
+
+	return errs.err()
+}
+
+// ValidateCreateAPIKey validates create API key request
+func ValidateCreateAPIKey(req dto.CreateAPIKeyRequest) error {
+	var errs fieldErrors
+
+	if strings.TrimSpace(req.Name) == "" {
+		errs.add("name", apperror.FieldRequired, "nama API key tidak boleh kosong", nil)
+	}
+
+	for i, scope := range req.Scopes {
+		if !utils.Contains(config.ValidScopes(), scope) {
+			errs.add(fmt.Sprintf("scopes[%d]", i), apperror.FieldInvalidChoice,
+				fmt.Sprintf("scope %q tidak valid. Pilihan: %s", scope, strings.Join(config.ValidScopes(), ", ")),
+				map[string]interface{}{"choices": config.ValidScopes()})
+		}
+	}
+
+	return errs.err()
+}
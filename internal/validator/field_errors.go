@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"football-management-api/internal/apperror"
+)
+
+// fieldErrors collects the failed rules of a request so that every invalid field is reported
+// at once instead of only the first one
+type fieldErrors []apperror.FieldError
+
+// add records a failed rule of a field
+func (e *fieldErrors) add(field, code, message string, params map[string]interface{}) {
+	*e = append(*e, apperror.FieldError{Field: field, Code: code, Message: message, Params: params})
+}
+
+// addErr records a typed error, such as an invalid cursor, as a failed rule of a field. The
+// error code becomes the code of the field.
+func (e *fieldErrors) addErr(field string, err error) {
+	code := apperror.FieldInvalid
+	if appErr, ok := apperror.As(err); ok {
+		code = appErr.Code
+	}
+	e.add(field, code, err.Error(), nil)
+}
+
+// has reports whether a rule of the field already failed
+func (e fieldErrors) has(field string) bool {
+	for _, fieldErr := range e {
+		if fieldErr.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// err returns a validation error listing the collected rules, or nil if none failed
+func (e fieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return apperror.InvalidFields(e)
+}
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/utils"
+)
+
+// fieldCodes returns the code of each failed field of a validation error, nil for no error
+func fieldCodes(t *testing.T, err error) map[string]string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+
+	appErr, ok := apperror.As(err)
+	if !ok || appErr.Code != apperror.CodeValidationFailed {
+		t.Fatalf("error = %v, want a validation error", err)
+	}
+
+	codes := map[string]string{}
+	for _, field := range appErr.Fields {
+		if _, ok := codes[field.Field]; ok {
+			t.Errorf("field %s reported twice", field.Field)
+		}
+		codes[field.Field] = field.Code
+	}
+	return codes
+}
+
+// checkFieldCodes checks that err failed exactly the fields of want with their codes
+func checkFieldCodes(t *testing.T, err error, want map[string]string) {
+	t.Helper()
+	if got := fieldCodes(t, err); !reflect.DeepEqual(got, want) {
+		t.Errorf("failed fields = %v, want %v", got, want)
+	}
+}
+
+func TestFieldErrors(t *testing.T) {
+	var errs fieldErrors
+	if err := errs.err(); err != nil {
+		t.Fatalf("err() = %v, want nil", err)
+	}
+
+	errs.add("name", apperror.FieldRequired, "nama wajib diisi", nil)
+	errs.addErr("cursor", utils.ErrInvalidCursor)
+
+	if !errs.has("name") || !errs.has("cursor") || errs.has("sort") {
+		t.Errorf("has() does not match the added fields %v", errs)
+	}
+
+	err := errs.err()
+	checkFieldCodes(t, err, map[string]string{"name": apperror.FieldRequired, "cursor": "INVALID_CURSOR"})
+
+	appErr, _ := apperror.As(err)
+	if want := "nama wajib diisi; cursor tidak valid"; appErr.Message != want {
+		t.Errorf("Message = %q, want %q", appErr.Message, want)
+	}
+}
@@ -1,23 +1,63 @@
 package validator
 
 import (
-	"errors"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 )
 
 // ValidateCreateGoal validates create goal request
 func ValidateCreateGoal(req dto.CreateGoalRequest) error {
+	var errs fieldErrors
+
 	if req.MatchID <= 0 {
-		return errors.New("match_id tidak valid")
+		errs.add("match_id", apperror.FieldInvalid, "match_id tidak valid", nil)
+	}
+
+	if req.PlayerID <= 0 {
+		errs.add("player_id", apperror.FieldInvalid, "player_id tidak valid", nil)
+	}
+
+	if req.GoalTime == "" {
+		errs.add("goal_time", apperror.FieldRequired, "waktu gol wajib diisi", nil)
 	}
 
+	return errs.err()
+}
+
+// ValidateUpdateGoal validates update goal request
+func ValidateUpdateGoal(req dto.UpdateGoalRequest) error {
+	var errs fieldErrors
+
 	if req.PlayerID <= 0 {
-		return errors.New("player_id tidak valid")
+		errs.add("player_id", apperror.FieldInvalid, "player_id tidak valid", nil)
 	}
 
 	if req.GoalTime == "" {
-		return errors.New("waktu gol wajib diisi")
+		errs.add("goal_time", apperror.FieldRequired, "waktu gol wajib diisi", nil)
 	}
 
-	return nil
+	return errs.err()
+}
+
+// ValidateGoalListQuery validates goal list filters and sorting
+func ValidateGoalListQuery(query dto.GoalListQuery) error {
+	var errs fieldErrors
+
+	if query.MatchID < 0 {
+		errs.add("match_id", apperror.FieldInvalid, "filter ID tidak valid", nil)
+	}
+
+	if query.PlayerID < 0 {
+		errs.add("player_id", apperror.FieldInvalid, "filter ID tidak valid", nil)
+	}
+
+	if query.TeamID < 0 {
+		errs.add("team_id", apperror.FieldInvalid, "filter ID tidak valid", nil)
+	}
+
+	checkSort(&errs, query.Sort, config.GoalSortFields())
+	checkCursor(&errs, query.Cursor, query.Sort)
+
+	return errs.err()
 }
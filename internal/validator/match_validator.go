@@ -1,7 +1,8 @@
 package validator
 
 import (
-	"errors"
+	"fmt"
+	"football-management-api/internal/apperror"
 	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/utils"
@@ -9,97 +10,132 @@ import (
 
 // ValidateCreateMatch validates create match request
 func ValidateCreateMatch(req dto.CreateMatchRequest) error {
-	if req.MatchDate == "" {
-		return errors.New("tanggal pertandingan wajib diisi")
-	}
+	var errs fieldErrors
+	checkMatch(&errs, req)
+	return errs.err()
+}
 
-	// Validate date format
-	if _, err := utils.ParseDate(req.MatchDate); err != nil {
-		return errors.New("format tanggal tidak valid. Gunakan format YYYY-MM-DD")
+// checkMatch checks the schedule and teams of a match
+func checkMatch(errs *fieldErrors, req dto.CreateMatchRequest) {
+	if req.MatchDate == "" {
+		errs.add("match_date", apperror.FieldRequired, "tanggal pertandingan wajib diisi", nil)
+	} else if _, err := utils.ParseDate(req.MatchDate); err != nil {
+		errs.add("match_date", apperror.FieldInvalidFormat, "format tanggal tidak valid. Gunakan format YYYY-MM-DD",
+			map[string]interface{}{"format": "YYYY-MM-DD"})
 	}
 
 	if req.MatchTime == "" {
-		return errors.New("waktu pertandingan wajib diisi")
-	}
-
-	// Validate time format
-	if _, err := utils.ParseTime(req.MatchTime); err != nil {
-		return errors.New("format waktu tidak valid. Gunakan format HH:MM:SS")
+		errs.add("match_time", apperror.FieldRequired, "waktu pertandingan wajib diisi", nil)
+	} else if _, err := utils.ParseTime(req.MatchTime); err != nil {
+		errs.add("match_time", apperror.FieldInvalidFormat, "format waktu tidak valid. Gunakan format HH:MM:SS",
+			map[string]interface{}{"format": "HH:MM:SS"})
 	}
 
 	if req.HomeTeamID <= 0 {
-		return errors.New("home_team_id tidak valid")
+		errs.add("home_team_id", apperror.FieldInvalid, "home_team_id tidak valid", nil)
 	}
 
 	if req.AwayTeamID <= 0 {
-		return errors.New("away_team_id tidak valid")
-	}
-
-	if req.HomeTeamID == req.AwayTeamID {
-		return errors.New("tim home dan away tidak boleh sama")
+		errs.add("away_team_id", apperror.FieldInvalid, "away_team_id tidak valid", nil)
+	} else if req.HomeTeamID == req.AwayTeamID {
+		errs.add("away_team_id", apperror.FieldSameAs, "tim home dan away tidak boleh sama",
+			map[string]interface{}{"field": "home_team_id"})
 	}
-
-	return nil
 }
 
 // ValidateUpdateMatch validates update match request
 func ValidateUpdateMatch(req dto.UpdateMatchRequest) error {
-	if req.MatchDate != "" {
-		if _, err := utils.ParseDate(req.MatchDate); err != nil {
-			return errors.New("format tanggal tidak valid. Gunakan format YYYY-MM-DD")
-		}
+	var errs fieldErrors
+
+	checkMatch(&errs, dto.CreateMatchRequest{
+		MatchDate:  req.MatchDate,
+		MatchTime:  req.MatchTime,
+		HomeTeamID: req.HomeTeamID,
+		AwayTeamID: req.AwayTeamID,
+	})
+	checkMatchStatus(&errs, req.Status)
+
+	return errs.err()
+}
+
+// checkMatchStatus checks that a match status is known
+func checkMatchStatus(errs *fieldErrors, status string) {
+	if !utils.Contains(config.ValidMatchStatuses(), status) {
+		errs.add("status", apperror.FieldInvalidChoice,
+			"status tidak valid. Pilihan: Scheduled, Live, Completed, Cancelled",
+			map[string]interface{}{"choices": config.ValidMatchStatuses()})
+	}
+}
+
+// ValidateMatchListQuery validates match list filters and sorting
+func ValidateMatchListQuery(query dto.MatchListQuery) error {
+	var errs fieldErrors
+
+	if query.Status != "" {
+		checkMatchStatus(&errs, query.Status)
 	}
 
-	if req.MatchTime != "" {
-		if _, err := utils.ParseTime(req.MatchTime); err != nil {
-			return errors.New("format waktu tidak valid. Gunakan format HH:MM:SS")
-		}
+	checkDateRange(&errs, query.DateFrom, query.DateTo)
+	checkIncludes(&errs, query.Include, config.MatchIncludes())
+	checkSort(&errs, query.Sort, config.MatchSortFields())
+	checkCursor(&errs, query.Cursor, query.Sort)
+
+	return errs.err()
+}
+
+// ValidateTeamMatchesQuery validates team fixtures and results filters
+func ValidateTeamMatchesQuery(query dto.TeamMatchesQuery) error {
+	var errs fieldErrors
+
+	if query.Upcoming && query.Past {
+		errs.add("past", apperror.FieldConflictsWith, "filter upcoming dan past tidak boleh digunakan bersamaan",
+			map[string]interface{}{"field": "upcoming"})
 	}
 
-	if req.HomeTeamID != 0 && req.AwayTeamID != 0 {
-		if req.HomeTeamID == req.AwayTeamID {
-			return errors.New("tim home dan away tidak boleh sama")
-		}
+	if query.Home && query.Away {
+		errs.add("away", apperror.FieldConflictsWith, "filter home dan away tidak boleh digunakan bersamaan",
+			map[string]interface{}{"field": "home"})
 	}
 
-	if req.Status != "" {
-		if !utils.Contains(config.ValidMatchStatuses(), req.Status) {
-			return errors.New("status tidak valid. Pilihan: Scheduled, Completed, Cancelled")
-		}
+	if query.Status != "" {
+		checkMatchStatus(&errs, query.Status)
 	}
 
-	return nil
+	checkDateRange(&errs, query.DateFrom, query.DateTo)
+
+	return errs.err()
 }
 
 // ValidateUpdateMatchResult validates update match result request
 func ValidateUpdateMatchResult(req dto.UpdateMatchResultRequest) error {
+	var errs fieldErrors
+
 	if req.HomeScore < 0 {
-		return errors.New("skor home tidak boleh negatif")
+		errs.add("home_score", apperror.FieldMin, "skor home tidak boleh negatif", map[string]interface{}{"min": 0})
 	}
 
 	if req.AwayScore < 0 {
-		return errors.New("skor away tidak boleh negatif")
-	}
-
-	if len(req.Goals) == 0 {
-		if req.HomeScore > 0 || req.AwayScore > 0 {
-			return errors.New("detail gol wajib diisi jika ada skor")
-		}
+		errs.add("away_score", apperror.FieldMin, "skor away tidak boleh negatif", map[string]interface{}{"min": 0})
 	}
 
 	totalGoals := req.HomeScore + req.AwayScore
-	if len(req.Goals) != totalGoals {
-		return errors.New("jumlah detail gol harus sama dengan total skor")
+	if len(req.Goals) == 0 && (req.HomeScore > 0 || req.AwayScore > 0) {
+		errs.add("goals", apperror.FieldRequired, "detail gol wajib diisi jika ada skor", nil)
+	} else if len(req.Goals) != totalGoals && !errs.has("home_score") && !errs.has("away_score") {
+		errs.add("goals", apperror.FieldCountMismatch, "jumlah detail gol harus sama dengan total skor",
+			map[string]interface{}{"expected": totalGoals, "actual": len(req.Goals)})
 	}
 
 	for i, goal := range req.Goals {
 		if goal.PlayerID <= 0 {
-			return errors.New("player_id pada detail gol ke-" + string(rune(i+1)) + " tidak valid")
+			errs.add(fmt.Sprintf("goals[%d].player_id", i), apperror.FieldInvalid,
+				fmt.Sprintf("player_id pada detail gol ke-%d tidak valid", i+1), nil)
 		}
 		if goal.GoalTime == "" {
-			return errors.New("waktu gol pada detail gol ke-" + string(rune(i+1)) + " wajib diisi")
+			errs.add(fmt.Sprintf("goals[%d].goal_time", i), apperror.FieldRequired,
+				fmt.Sprintf("waktu gol pada detail gol ke-%d wajib diisi", i+1), nil)
 		}
 	}
 
-	return nil
+	return errs.err()
 }
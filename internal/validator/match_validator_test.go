@@ -0,0 +1,113 @@
+package validator
+
+import (
+	"testing"
+
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/dto"
+)
+
+func TestValidateCreateMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		req  dto.CreateMatchRequest
+		want map[string]string
+	}{
+		{"valid", dto.CreateMatchRequest{MatchDate: "2024-10-01", MatchTime: "19:00:00", HomeTeamID: 1, AwayTeamID: 2}, nil},
+		{"missing fields", dto.CreateMatchRequest{}, map[string]string{
+			"match_date":   apperror.FieldRequired,
+			"match_time":   apperror.FieldRequired,
+			"home_team_id": apperror.FieldInvalid,
+			"away_team_id": apperror.FieldInvalid,
+		}},
+		{"invalid formats", dto.CreateMatchRequest{MatchDate: "01/10/2024", MatchTime: "7pm", HomeTeamID: 1, AwayTeamID: 2},
+			map[string]string{
+				"match_date": apperror.FieldInvalidFormat,
+				"match_time": apperror.FieldInvalidFormat,
+			}},
+		{"same teams", dto.CreateMatchRequest{MatchDate: "2024-10-01", MatchTime: "19:00:00", HomeTeamID: 1, AwayTeamID: 1},
+			map[string]string{"away_team_id": apperror.FieldSameAs}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldCodes(t, ValidateCreateMatch(tt.req), tt.want)
+		})
+	}
+}
+
+func TestValidateUpdateMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		req  dto.UpdateMatchRequest
+		want map[string]string
+	}{
+		{"valid", dto.UpdateMatchRequest{MatchDate: "2024-10-01", MatchTime: "19:00:00", HomeTeamID: 1, AwayTeamID: 2, Status: "Live"}, nil},
+		{"unknown status with same teams", dto.UpdateMatchRequest{MatchDate: "2024-10-01", MatchTime: "19:00:00", HomeTeamID: 1, AwayTeamID: 1, Status: "Postponed"},
+			map[string]string{
+				"away_team_id": apperror.FieldSameAs,
+				"status":       apperror.FieldInvalidChoice,
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldCodes(t, ValidateUpdateMatch(tt.req), tt.want)
+		})
+	}
+}
+
+func TestValidateTeamMatchesQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query dto.TeamMatchesQuery
+		want  map[string]string
+	}{
+		{"no filters", dto.TeamMatchesQuery{}, nil},
+		{"upcoming home matches", dto.TeamMatchesQuery{Upcoming: true, Home: true}, nil},
+		{"conflicting filters", dto.TeamMatchesQuery{Upcoming: true, Past: true, Home: true, Away: true, Status: "Postponed"},
+			map[string]string{
+				"past":   apperror.FieldConflictsWith,
+				"away":   apperror.FieldConflictsWith,
+				"status": apperror.FieldInvalidChoice,
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldCodes(t, ValidateTeamMatchesQuery(tt.query), tt.want)
+		})
+	}
+}
+
+func TestValidateUpdateMatchResult(t *testing.T) {
+	goal := func(playerID int, goalTime string) dto.GoalInputDetail {
+		return dto.GoalInputDetail{PlayerID: playerID, GoalTime: goalTime}
+	}
+
+	tests := []struct {
+		name string
+		req  dto.UpdateMatchResultRequest
+		want map[string]string
+	}{
+		{"goalless draw", dto.UpdateMatchResultRequest{}, nil},
+		{"goals match the score", dto.UpdateMatchResultRequest{HomeScore: 1, AwayScore: 1, Goals: []dto.GoalInputDetail{goal(1, "10"), goal(5, "45+2")}}, nil},
+		{"score without goals", dto.UpdateMatchResultRequest{HomeScore: 2},
+			map[string]string{"goals": apperror.FieldRequired}},
+		{"goal count mismatch", dto.UpdateMatchResultRequest{HomeScore: 2, Goals: []dto.GoalInputDetail{goal(1, "10")}},
+			map[string]string{"goals": apperror.FieldCountMismatch}},
+		{"negative score hides the count mismatch", dto.UpdateMatchResultRequest{HomeScore: -1, AwayScore: 1, Goals: []dto.GoalInputDetail{goal(1, "10")}},
+			map[string]string{"home_score": apperror.FieldMin}},
+		{"invalid goal details", dto.UpdateMatchResultRequest{HomeScore: 2, Goals: []dto.GoalInputDetail{goal(0, "10"), goal(1, "")}},
+			map[string]string{
+				"goals[0].player_id": apperror.FieldInvalid,
+				"goals[1].goal_time": apperror.FieldRequired,
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldCodes(t, ValidateUpdateMatchResult(tt.req), tt.want)
+		})
+	}
+}
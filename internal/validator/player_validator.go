@@ -1,7 +1,7 @@
 package validator
 
 import (
-	"errors"
+	"football-management-api/internal/apperror"
 	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 	"football-management-api/internal/utils"
@@ -9,62 +9,65 @@ import (
 
 // ValidateCreatePlayer validates create player request
 func ValidateCreatePlayer(req dto.CreatePlayerRequest) error {
+	var errs fieldErrors
+
 	if req.TeamID <= 0 {
-		return errors.New("team_id tidak valid")
+		errs.add("team_id", apperror.FieldInvalid, "team_id tidak valid", nil)
 	}
 
 	if req.Name == "" {
-		return errors.New("nama pemain wajib diisi")
+		errs.add("name", apperror.FieldRequired, "nama pemain wajib diisi", nil)
 	}
 
 	if req.Height < 100 || req.Height > 250 {
-		return errors.New("tinggi badan harus antara 100-250 cm")
+		errs.add("height", apperror.FieldOutOfRange, "tinggi badan harus antara 100-250 cm",
+			map[string]interface{}{"min": 100, "max": 250})
 	}
 
 	if req.Weight < 30 || req.Weight > 200 {
-		return errors.New("berat badan harus antara 30-200 kg")
+		errs.add("weight", apperror.FieldOutOfRange, "berat badan harus antara 30-200 kg",
+			map[string]interface{}{"min": 30, "max": 200})
 	}
 
 	if !utils.Contains(config.ValidPlayerPositions(), req.Position) {
-		return errors.New("posisi pemain tidak valid. Pilihan: Penyerang, Gelandang, Bertahan, Penjaga Gawang")
+		errs.add("position", apperror.FieldInvalidChoice,
+			"posisi pemain tidak valid. Pilihan: Penyerang, Gelandang, Bertahan, Penjaga Gawang",
+			map[string]interface{}{"choices": config.ValidPlayerPositions()})
 	}
 
 	if req.JerseyNumber < 1 || req.JerseyNumber > 99 {
-		return errors.New("nomor punggung harus antara 1-99")
+		errs.add("jersey_number", apperror.FieldOutOfRange, "nomor punggung harus antara 1-99",
+			map[string]interface{}{"min": 1, "max": 99})
 	}
 
-	return nil
+	return errs.err()
 }
 
 // ValidateUpdatePlayer validates update player request
 func ValidateUpdatePlayer(req dto.UpdatePlayerRequest) error {
-	if req.TeamID < 0 {
-		return errors.New("team_id tidak valid")
-	}
+	return ValidateCreatePlayer(dto.CreatePlayerRequest(req))
+}
 
-	if req.Height != 0 {
-		if req.Height < 100 || req.Height > 250 {
-			return errors.New("tinggi badan harus antara 100-250 cm")
-		}
-	}
+// ValidatePlayerListQuery validates player list filters and sorting
+func ValidatePlayerListQuery(query dto.PlayerListQuery) error {
+	var errs fieldErrors
 
-	if req.Weight != 0 {
-		if req.Weight < 30 || req.Weight > 200 {
-			return errors.New("berat badan harus antara 30-200 kg")
-		}
+	if query.TeamID < 0 {
+		errs.add("team_id", apperror.FieldInvalid, "team_id tidak valid", nil)
 	}
 
-	if req.Position != "" {
-		if !utils.Contains(config.ValidPlayerPositions(), req.Position) {
-			return errors.New("posisi pemain tidak valid. Pilihan: Penyerang, Gelandang, Bertahan, Penjaga Gawang")
+	if query.Position != "" {
+		if !utils.Contains(config.ValidPlayerPositions(), query.Position) {
+			errs.add("position", apperror.FieldInvalidChoice,
+				"posisi pemain tidak valid. Pilihan: Penyerang, Gelandang, Bertahan, Penjaga Gawang",
+				map[string]interface{}{"choices": config.ValidPlayerPositions()})
 		}
 	}
 
-	if req.JerseyNumber != 0 {
-		if req.JerseyNumber < 1 || req.JerseyNumber > 99 {
-			return errors.New("nomor punggung harus antara 1-99")
-		}
-	}
+	checkRange(&errs, "height_min", "height_max", query.HeightMin, query.HeightMax, "tinggi badan")
+	checkIncludes(&errs, query.Include, config.PlayerIncludes())
+	checkSort(&errs, query.Sort, config.PlayerSortFields())
+	checkCursor(&errs, query.Cursor, query.Sort)
 
-	return nil
+	return errs.err()
 }
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"testing"
+
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/dto"
+)
+
+func TestValidateCreatePlayer(t *testing.T) {
+	valid := dto.CreatePlayerRequest{
+		TeamID:       1,
+		Name:         "Bambang Pamungkas",
+		Height:       172,
+		Weight:       68,
+		Position:     "Penyerang",
+		JerseyNumber: 20,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(req *dto.CreatePlayerRequest)
+		want   map[string]string
+	}{
+		{"valid", func(req *dto.CreatePlayerRequest) {}, nil},
+		{"boundary values", func(req *dto.CreatePlayerRequest) {
+			req.Height, req.Weight, req.JerseyNumber = 250, 30, 99
+		}, nil},
+		{"missing team", func(req *dto.CreatePlayerRequest) { req.TeamID = 0 },
+			map[string]string{"team_id": apperror.FieldInvalid}},
+		{"height out of range", func(req *dto.CreatePlayerRequest) { req.Height = 99.5 },
+			map[string]string{"height": apperror.FieldOutOfRange}},
+		{"unknown position", func(req *dto.CreatePlayerRequest) { req.Position = "Libero" },
+			map[string]string{"position": apperror.FieldInvalidChoice}},
+		{"every field invalid", func(req *dto.CreatePlayerRequest) {
+			*req = dto.CreatePlayerRequest{Weight: 250, JerseyNumber: 100}
+		}, map[string]string{
+			"team_id":       apperror.FieldInvalid,
+			"name":          apperror.FieldRequired,
+			"height":        apperror.FieldOutOfRange,
+			"weight":        apperror.FieldOutOfRange,
+			"position":      apperror.FieldInvalidChoice,
+			"jersey_number": apperror.FieldOutOfRange,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			checkFieldCodes(t, ValidateCreatePlayer(req), tt.want)
+		})
+	}
+}
+
+func TestValidatePlayerListQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query dto.PlayerListQuery
+		want  map[string]string
+	}{
+		{"no filters", dto.PlayerListQuery{}, nil},
+		{"valid filters", dto.PlayerListQuery{TeamID: 1, Position: "Bertahan", HeightMin: 170, HeightMax: 190, Sort: "-height,name"}, nil},
+		{"several invalid filters", dto.PlayerListQuery{TeamID: -1, Position: "Libero", HeightMin: 190, HeightMax: 170, Sort: "age"},
+			map[string]string{
+				"team_id":    apperror.FieldInvalid,
+				"position":   apperror.FieldInvalidChoice,
+				"height_min": apperror.FieldOutOfRange,
+				"sort":       apperror.FieldInvalidChoice,
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldCodes(t, ValidatePlayerListQuery(tt.query), tt.want)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"fmt"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/utils"
+	"strings"
+	"time"
+)
+
+// checkSort checks that every field of a sort parameter is allowed
+func checkSort(errs *fieldErrors, sort string, allowed []string) {
+	for _, field := range utils.ParseSort(sort) {
+		if !utils.Contains(allowed, field.Field) {
+			errs.add("sort", apperror.FieldInvalidChoice,
+				fmt.Sprintf("field sort %s tidak valid. Pilihan: %s", field.Field, strings.Join(allowed, ", ")),
+				map[string]interface{}{"value": field.Field, "choices": allowed})
+			return
+		}
+	}
+}
+
+// checkRange checks that an optional minimum is not greater than an optional maximum
+func checkRange(errs *fieldErrors, minField, maxField string, min, max float64, name string) {
+	if min < 0 {
+		errs.add(minField, apperror.FieldMin, fmt.Sprintf("filter %s tidak boleh negatif", name), map[string]interface{}{"min": 0})
+	}
+
+	if max < 0 {
+		errs.add(maxField, apperror.FieldMin, fmt.Sprintf("filter %s tidak boleh negatif", name), map[string]interface{}{"min": 0})
+	}
+
+	if min > 0 && max > 0 && min > max {
+		errs.add(minField, apperror.FieldOutOfRange,
+			fmt.Sprintf("nilai minimum %s tidak boleh lebih besar dari nilai maksimum", name),
+			map[string]interface{}{"max": max})
+	}
+}
+
+// checkCursor checks an optional pagination cursor against the requested sort
+func checkCursor(errs *fieldErrors, cursor *string, sort string) {
+	if cursor == nil {
+		return
+	}
+
+	decoded, err := utils.DecodeCursor(*cursor)
+	if err != nil {
+		errs.addErr("cursor", err)
+		return
+	}
+
+	if len(decoded.Values) > 0 && decoded.Sort != utils.FormatSort(utils.ParseSort(sort)) {
+		errs.addErr("cursor", utils.ErrCursorSortMismatch)
+	}
+}
+
+// checkIncludes checks that every relation of an include parameter can be embedded
+func checkIncludes(errs *fieldErrors, include string, allowed []string) {
+	for _, relation := range utils.ParseIncludes(include) {
+		if !utils.Contains(allowed, relation) {
+			errs.add("include", apperror.FieldInvalidChoice,
+				fmt.Sprintf("include %s tidak valid. Pilihan: %s", relation, strings.Join(allowed, ", ")),
+				map[string]interface{}{"value": relation, "choices": allowed})
+			return
+		}
+	}
+}
+
+// checkDateRange checks an optional date_from and date_to filter
+func checkDateRange(errs *fieldErrors, dateFrom, dateTo string) {
+	var from, to time.Time
+	var err error
+
+	if dateFrom != "" {
+		if from, err = utils.ParseDate(dateFrom); err != nil {
+			errs.add("date_from", apperror.FieldInvalidFormat, "format date_from tidak valid. Gunakan format YYYY-MM-DD",
+				map[string]interface{}{"format": "YYYY-MM-DD"})
+		}
+	}
+
+	if dateTo != "" {
+		if to, err = utils.ParseDate(dateTo); err != nil {
+			errs.add("date_to", apperror.FieldInvalidFormat, "format date_to tidak valid. Gunakan format YYYY-MM-DD",
+				map[string]interface{}{"format": "YYYY-MM-DD"})
+		}
+	}
+
+	if errs.has("date_from") || errs.has("date_to") {
+		return
+	}
+
+	if dateFrom != "" && dateTo != "" && to.Before(from) {
+		errs.add("date_to", apperror.FieldBefore, "date_to tidak boleh sebelum date_from",
+			map[string]interface{}{"field": "date_from"})
+	}
+}
+
+// ValidateIncludes validates that every relation of an include parameter can be embedded
+func ValidateIncludes(include string, allowed []string) error {
+	var errs fieldErrors
+	checkIncludes(&errs, include, allowed)
+	return errs.err()
+}
+
+// ValidateDateRange validates an optional date range filter
+func ValidateDateRange(dateFrom, dateTo string) error {
+	var errs fieldErrors
+	checkDateRange(&errs, dateFrom, dateTo)
+	return errs.err()
+}
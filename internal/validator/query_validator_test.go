@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"testing"
+
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/models"
+	"football-management-api/internal/utils"
+)
+
+func TestCheckSort(t *testing.T) {
+	allowed := []string{"name", "founded_year"}
+
+	tests := []struct {
+		sort string
+		want map[string]string
+	}{
+		{"", nil},
+		{"name", nil},
+		{"-founded_year,name", nil},
+		{"stadium", map[string]string{"sort": apperror.FieldInvalidChoice}},
+		{"name,-stadium,city", map[string]string{"sort": apperror.FieldInvalidChoice}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			var errs fieldErrors
+			checkSort(&errs, tt.sort, allowed)
+			checkFieldCodes(t, errs.err(), tt.want)
+		})
+	}
+}
+
+func TestCheckRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+		want     map[string]string
+	}{
+		{"no filter", 0, 0, nil},
+		{"minimum only", 170, 0, nil},
+		{"maximum only", 0, 180, nil},
+		{"valid range", 170, 180, nil},
+		{"equal bounds", 175, 175, nil},
+		{"minimum above maximum", 180, 170, map[string]string{"height_min": apperror.FieldOutOfRange}},
+		{"negative bounds", -1, -2, map[string]string{"height_min": apperror.FieldMin, "height_max": apperror.FieldMin}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs fieldErrors
+			checkRange(&errs, "height_min", "height_max", tt.min, tt.max, "tinggi badan")
+			checkFieldCodes(t, errs.err(), tt.want)
+		})
+	}
+}
+
+func TestCheckCursor(t *testing.T) {
+	cursor := func(c *models.Cursor) *string {
+		encoded := utils.EncodeCursor(c)
+		return &encoded
+	}
+	invalid := "%%%"
+	empty := ""
+
+	tests := []struct {
+		name   string
+		cursor *string
+		sort   string
+		want   map[string]string
+	}{
+		{"no cursor", nil, "name", nil},
+		{"first page", &empty, "name", nil},
+		{"cursor of the sort", cursor(&models.Cursor{Sort: "-name", Values: []interface{}{"A", 1}}), "-name", nil},
+		{"cursor of another sort", cursor(&models.Cursor{Sort: "-name", Values: []interface{}{"A", 1}}), "name",
+			map[string]string{"cursor": "CURSOR_SORT_MISMATCH"}},
+		{"malformed cursor", &invalid, "name", map[string]string{"cursor": "INVALID_CURSOR"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs fieldErrors
+			checkCursor(&errs, tt.cursor, tt.sort)
+			checkFieldCodes(t, errs.err(), tt.want)
+		})
+	}
+}
+
+func TestCheckIncludes(t *testing.T) {
+	allowed := []string{"players", "matches", "matches.goals"}
+
+	tests := []struct {
+		include string
+		want    map[string]string
+	}{
+		{"", nil},
+		{"players,matches.goals", nil},
+		{"players,coach", map[string]string{"include": apperror.FieldInvalidChoice}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.include, func(t *testing.T) {
+			checkFieldCodes(t, ValidateIncludes(tt.include, allowed), tt.want)
+		})
+	}
+}
+
+func TestCheckDateRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		want     map[string]string
+	}{
+		{"no filter", "", "", nil},
+		{"from only", "2024-10-01", "", nil},
+		{"to only", "", "2024-10-31", nil},
+		{"valid range", "2024-10-01", "2024-10-31", nil},
+		{"same day", "2024-10-01", "2024-10-01", nil},
+		{"to before from", "2024-10-31", "2024-10-01", map[string]string{"date_to": apperror.FieldBefore}},
+		{"invalid from", "01-10-2024", "2024-10-01", map[string]string{"date_from": apperror.FieldInvalidFormat}},
+		{"both invalid", "kemarin", "besok", map[string]string{
+			"date_from": apperror.FieldInvalidFormat,
+			"date_to":   apperror.FieldInvalidFormat,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkFieldCodes(t, ValidateDateRange(tt.from, tt.to), tt.want)
+		})
+	}
+}
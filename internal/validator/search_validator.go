@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"fmt"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
+	"football-management-api/internal/dto"
+	"football-management-api/internal/utils"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateSearchQuery validates global search parameters
+func ValidateSearchQuery(query dto.SearchQuery) error {
+	var errs fieldErrors
+
+	if utf8.RuneCountInString(strings.TrimSpace(query.Q)) < config.SearchMinQueryLength {
+		errs.add("q", apperror.FieldLength,
+			fmt.Sprintf("kata kunci pencarian minimal %d karakter", config.SearchMinQueryLength),
+			map[string]interface{}{"min": config.SearchMinQueryLength})
+	}
+
+	if query.Type != "" {
+		for _, t := range strings.Split(query.Type, ",") {
+			if !utils.Contains(config.ValidSearchTypes(), strings.TrimSpace(t)) {
+				errs.add("type", apperror.FieldInvalidChoice,
+					fmt.Sprintf("tipe pencarian %s tidak valid. Pilihan: team, player, match", t),
+					map[string]interface{}{"value": t, "choices": config.ValidSearchTypes()})
+				break
+			}
+		}
+	}
+
+	if query.Limit < 0 || query.Limit > config.SearchMaxLimit {
+		errs.add("limit", apperror.FieldOutOfRange, fmt.Sprintf("limit harus antara 1-%d", config.SearchMaxLimit),
+			map[string]interface{}{"min": 1, "max": config.SearchMaxLimit})
+	}
+
+	return errs.err()
+}
@@ -1,38 +1,48 @@
 package validator
 
 import (
-	"errors"
+	"football-management-api/internal/apperror"
+	"football-management-api/internal/config"
 	"football-management-api/internal/dto"
 )
 
 // ValidateCreateTeam validates create team request
 func ValidateCreateTeam(req dto.CreateTeamRequest) error {
+	var errs fieldErrors
+
 	if req.Name == "" {
-		return errors.New("nama tim wajib diisi")
+		errs.add("name", apperror.FieldRequired, "nama tim wajib diisi", nil)
 	}
 
 	if req.FoundedYear < 1800 || req.FoundedYear > 2100 {
-		return errors.New("tahun berdiri tidak valid")
+		errs.add("founded_year", apperror.FieldOutOfRange, "tahun berdiri tidak valid",
+			map[string]interface{}{"min": 1800, "max": 2100})
 	}
 
 	if req.HomeAddress == "" {
-		return errors.New("alamat markas wajib diisi")
+		errs.add("home_address", apperror.FieldRequired, "alamat markas wajib diisi", nil)
 	}
 
 	if req.HomeCity == "" {
-		return errors.New("kota markas wajib diisi")
+		errs.add("home_city", apperror.FieldRequired, "kota markas wajib diisi", nil)
 	}
 
-	return nil
+	return errs.err()
 }
 
 // ValidateUpdateTeam validates update team request
 func ValidateUpdateTeam(req dto.UpdateTeamRequest) error {
-	if req.FoundedYear != 0 {
-		if req.FoundedYear < 1800 || req.FoundedYear > 2100 {
-			return errors.New("tahun berdiri tidak valid")
-		}
-	}
+	return ValidateCreateTeam(dto.CreateTeamRequest(req))
+}
+
+// ValidateTeamListQuery validates team list filters and sorting
+func ValidateTeamListQuery(query dto.TeamListQuery) error {
+	var errs fieldErrors
+
+	checkRange(&errs, "founded_from", "founded_to", float64(query.FoundedFrom), float64(query.FoundedTo), "tahun berdiri")
+	checkIncludes(&errs, query.Include, config.TeamIncludes())
+	checkSort(&errs, query.Sort, config.TeamSortFields())
+	checkCursor(&errs, query.Cursor, query.Sort)
 
-	return nil
+	return errs.err()
 }